@@ -1,3 +1,4 @@
+using System.Text.RegularExpressions;
 using DotNetEnv;
 using Microsoft.OpenApi;
 using Vite.AspNetCore;
@@ -179,6 +180,21 @@ try
                         pageName = route.Name,
                         seo = route.Seo
                     });
+
+                // Permanent redirects from previous paths (poyo route migrate-urls)
+                foreach (var redirect in route.Redirects ?? [])
+                {
+                    if (redirect.Equals(route.Path, StringComparison.OrdinalIgnoreCase)) continue;
+
+                    var target = route.Path;
+                    app.MapGet(redirect.TrimStart('/'), (HttpContext context) =>
+                    {
+                        // Carry route parameters such as {id} or {id:int} over to the new path
+                        var location = Regex.Replace(target, @"\{\*{0,2}([^}:=?]+)[^}]*\}", m =>
+                            Uri.EscapeDataString(context.Request.RouteValues[m.Groups[1].Value]?.ToString() ?? ""));
+                        return Results.Redirect(location, permanent: true);
+                    });
+                }
             }
         }
     }
@@ -196,7 +212,7 @@ app.MapControllerRoute(
 app.Run();
 
 // Helper record for deserialization
-internal record RouteDefinition(string Path, string Name, RouteFiles Files, bool IsPublic, bool IsGuestOnly, Poyo.Server.Models.SeoModel? Seo, string? Controller, string? Action);
+internal record RouteDefinition(string Path, string Name, RouteFiles Files, bool IsPublic, bool IsGuestOnly, Poyo.Server.Models.SeoModel? Seo, string? Controller, string? Action, List<string>? Redirects);
 internal record RouteFiles(string View);
 
 
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+	"poyo-cli/internal/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish|powershell",
+	Short: "Generate a shell completion script",
+	Long: `Generate a shell completion script for poyo.
+
+Route paths, controller names and layouts are completed from the current project.
+
+  Bash:        source <(poyo completion bash)
+  Zsh:         poyo completion zsh > "${fpath[1]}/_poyo"
+  Fish:        poyo completion fish > ~/.config/fish/completions/poyo.fish
+  PowerShell:  poyo completion powershell | Out-String | Invoke-Expression`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		switch args[0] {
+		case "bash":
+			return RootCmd.GenBashCompletionV2(out, true)
+		case "zsh":
+			return RootCmd.GenZshCompletion(out)
+		case "fish":
+			return RootCmd.GenFishCompletion(out, true)
+		case "powershell":
+			return RootCmd.GenPowerShellCompletionWithDesc(out)
+		}
+		return output.Errorf(output.ErrInvalidArgument, "unsupported shell %q (expected bash, zsh, fish or powershell)", args[0])
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(completionCmd)
+}
+
+// loadCompletionConfig picks up --root and --app for completion functions, which run without
+// PersistentPreRunE. Errors leave the layout guessed at startup.
+func loadCompletionConfig() {
+	if rootDir != "" || appName != "" {
+		config.Load(rootDir, appName)
+	}
+}
+
+// completeApps completes --app with the apps of the workspace.
+func completeApps(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if rootDir != "" {
+		config.Load(rootDir, "")
+	}
+	return config.AppNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRoutePaths completes the first argument with route paths from routes.json.
+func completeRoutePaths(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	loadCompletionConfig()
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	r, err := routes.Read(config.RoutesJSON)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var paths []string
+	for _, rt := range r {
+		if strings.HasPrefix(strings.ToLower(rt.Path), strings.ToLower(toComplete)) {
+			paths = append(paths, rt.Path+"\t"+rt.Name)
+		}
+	}
+	return paths, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeControllers completes --controller with MVC controllers (API controllers are skipped).
+func completeControllers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	loadCompletionConfig()
+	files, err := controllers.Scan(config.ControllersDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	apiDir := filepath.Join(config.ControllersDir, "Api") + string(filepath.Separator)
+	var names []string
+	for _, f := range files {
+		if strings.HasPrefix(f.Path, apiDir) {
+			continue
+		}
+		// Controllers in subfolders complete as "Admin/Reports"
+		name := strings.TrimSuffix(f.Name, "Controller")
+		if rel, err := filepath.Rel(config.ControllersDir, filepath.Dir(f.Path)); err == nil && rel != "." {
+			name = filepath.ToSlash(rel) + "/" + name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLayouts completes --layout with the Razor layouts found in Views/Shared.
+func completeLayouts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	loadCompletionConfig()
+	entries, err := os.ReadDir(filepath.Join(config.ViewsDir, "Shared"))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var layouts []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".cshtml") || strings.Contains(name, "Partial") {
+			continue
+		}
+		layouts = append(layouts, strings.TrimSuffix(name, ".cshtml"))
+	}
+	return layouts, cobra.ShellCompDirectiveNoFileComp
+}
+
+var completeURLStyles = cobra.FixedCompletions(
+	[]string{string(naming.PascalURL), string(naming.KebabURL), string(naming.PreserveURL)},
+	cobra.ShellCompDirectiveNoFileComp,
+)
+
+func completePageVariants(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return scaffold.PageVariants(), cobra.ShellCompDirectiveNoFileComp
+}
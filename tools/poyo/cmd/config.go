@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and edit poyo.config.json",
+	Long: `View and edit the project configuration in poyo.config.json (project root).
+
+Settings left empty are inferred: the client and server projects are the folders ending in
+.client and .Server, and the other paths follow the project template. Paths are relative to the
+project root.`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show every setting with its effective value",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigShow,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:               "get <key>",
+	Short:             "Print the effective value of a setting",
+	Example:           `  poyo config get paths.server`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigKeys,
+	RunE:              runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting (an empty value restores the default)",
+	Example: `  poyo config set paths.server src/Acme.Web
+  poyo config set naming.url kebab
+  poyo config set routeAdd.withTests true
+  poyo config set paths.server ""`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeConfigKeys,
+	RunE:              runConfigSet,
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd, configGetCmd, configSetCmd)
+	RootCmd.AddCommand(configCmd)
+}
+
+// setting is the effective value of a poyo.config.json key.
+type setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"` // "poyo.config.json", "inferred" or "default"
+}
+
+// settings lists every key with the value in use: configured, or inferred from the project.
+func settings(p config.Project) []setting {
+	strategy := naming.Current()
+	effective := map[string]string{
+		"paths.client":       fsutil.RelToRoot(config.ClientDir),
+		"paths.server":       fsutil.RelToRoot(config.ServerDir),
+		"paths.controllers":  fsutil.RelToRoot(config.ControllersDir),
+		"paths.pages":        fsutil.RelToRoot(config.PagesDir),
+		"paths.views":        fsutil.RelToRoot(config.ViewsDir),
+		"paths.routes":       fsutil.RelToRoot(config.RoutesJSON),
+		"paths.templates":    fsutil.RelToRoot(config.TemplatesDir),
+		"naming.url":         string(strategy.URL),
+		"naming.file":        string(strategy.File),
+		"routeAdd.flat":      strconv.FormatBool(p.RouteAdd.Flat),
+		"routeAdd.noView":    strconv.FormatBool(p.RouteAdd.NoView),
+		"routeAdd.withTests": strconv.FormatBool(p.RouteAdd.WithTests),
+	}
+
+	var list []setting
+	for _, key := range config.Keys() {
+		value, _ := p.Get(key)
+		s := setting{Key: key, Value: value, Source: filepath.Base(config.ProjectFile)}
+		if value == "" {
+			s.Value, s.Source = effective[key], "default"
+			if strings.HasPrefix(key, "paths.") {
+				s.Source = "inferred"
+			}
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	p, err := config.LoadProject()
+	if err != nil {
+		return err
+	}
+	list := settings(p)
+	output.Data("settings", list)
+
+	if config.AppName != "" {
+		output.Data("app", config.AppName)
+		output.Printf("Workspace:    %s (apps: %s)\n", config.WorkspaceDir, strings.Join(config.AppNames(), ", "))
+		output.Printf("App:          %s\n", config.AppName)
+	}
+	output.Printf("Project root: %s\n\n", config.RootDir)
+	for _, s := range list {
+		value := s.Value
+		if value == "" {
+			value = "-"
+		}
+		output.Printf("  %-20s %-32s %s\n", s.Key, value, s.Source)
+	}
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	p, err := config.LoadProject()
+	if err != nil {
+		return err
+	}
+	if _, err := p.Get(args[0]); err != nil {
+		return output.Errorf(output.ErrInvalidArgument, "%v", err)
+	}
+	for _, s := range settings(p) {
+		if strings.EqualFold(s.Key, args[0]) {
+			output.Data("setting", s)
+			output.Println(s.Value)
+		}
+	}
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	key, value := args[0], strings.TrimSpace(args[1])
+	for _, k := range config.Keys() {
+		if strings.EqualFold(k, key) {
+			key = k
+		}
+	}
+	if strings.HasPrefix(key, "paths.") && value != "" {
+		value = filepath.ToSlash(filepath.Clean(value))
+	}
+
+	p, err := config.LoadProject()
+	if err != nil {
+		return err
+	}
+	if err := p.Set(key, value); err != nil {
+		return output.Errorf(output.ErrInvalidArgument, "%v", err)
+	}
+
+	// Validate the whole configuration before saving it
+	if err := config.Apply(p); err != nil {
+		return output.Errorf(output.ErrInvalidPath, "%v", err)
+	}
+	if _, err := naming.Parse(p.Naming.URL, p.Naming.File); err != nil {
+		return output.Errorf(output.ErrInvalidArgument, "%v", err)
+	}
+	if key == "routeAdd.template" && value != "" && !scaffold.HasPageVariant(value) {
+		return output.Errorf(output.ErrInvalidArgument, "unknown page template %q (available: %s)", value, strings.Join(scaffold.PageVariants(), ", "))
+	}
+
+	if err := config.SaveProject(p); err != nil {
+		return err
+	}
+	if value == "" {
+		output.Modified(config.ProjectFile, "[UPDATED] %s: %s removed\n", filepath.Base(config.ProjectFile), key)
+	} else {
+		output.Modified(config.ProjectFile, "[UPDATED] %s: %s = %s\n", filepath.Base(config.ProjectFile), key, value)
+	}
+
+	// The client and server must exist (checked by Apply); the other folders may come later
+	if strings.HasPrefix(key, "paths.") && value != "" && key != "paths.routes" && key != "paths.templates" {
+		if _, err := os.Stat(filepath.Join(config.RootDir, filepath.FromSlash(value))); err != nil {
+			output.Printf("[WARN] %s does not exist\n", value)
+			output.Warn("%s does not exist", value)
+		}
+	}
+	return nil
+}
+
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return config.Keys(), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var makeCmd = &cobra.Command{
+	Use:   "make",
+	Short: "Generate code for existing routes and the client/server projects",
+}
+
+func init() {
+	RootCmd.AddCommand(makeCmd)
+}
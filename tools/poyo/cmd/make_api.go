@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiActions string
+	apiPublic  bool
+)
+
+var makeAPICmd = &cobra.Command{
+	Use:   "api <Name>",
+	Short: "Generate an API controller with its models and service",
+	Long: `Generate a JSend API following the Auth example:
+
+  Controllers/Api/<Name>Controller.cs              [ApiController] returning JSendResponse<T>
+  Models/<Name>/Requests/<Action><Name>Request.cs
+  Models/<Name>/Responses/<Action><Name>Response.cs
+  Services/<Name>/I<Name>Service.cs, <Name>Service.cs
+  Program.cs                                        AddScoped<I<Name>Service, <Name>Service>()
+
+The HTTP method follows the action name: Get/List/Search -> GET, Update -> PUT,
+Delete/Remove -> DELETE, others -> POST.`,
+	Example: `  poyo make api Products --actions Get,List,Create,Update,Delete`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runMakeAPI,
+}
+
+func init() {
+	makeAPICmd.Flags().StringVar(&apiActions, "actions", "Get", "Comma separated action names")
+	makeAPICmd.Flags().BoolVar(&apiPublic, "public", false, "Allow anonymous access ([AllowAnonymous] instead of [Authorize])")
+
+	makeCmd.AddCommand(makeAPICmd)
+}
+
+func runMakeAPI(cmd *cobra.Command, args []string) error {
+	name := naming.Pascal(strings.TrimSuffix(args[0], "Controller"))
+	if name == "" {
+		return output.Errorf(output.ErrInvalidArgument, "invalid API name %q", args[0])
+	}
+
+	actions := splitActions(apiActions)
+	if len(actions) == 0 {
+		return output.Errorf(output.ErrInvalidArgument, "--actions needs at least one action name")
+	}
+
+	ctx := scaffold.NewAPIContext(name, actions, apiPublic)
+	controller := filepath.Join(config.ServerDir, scaffold.APIFiles(ctx)[scaffold.APIControllerTmpl])
+	if _, err := os.Stat(controller); err == nil {
+		return output.Errorf(output.ErrInvalidArgument, "%sController already exists: %s", name, scaffold.APIFiles(ctx)[scaffold.APIControllerTmpl])
+	}
+
+	if err := scaffold.ScaffoldAPI(ctx); err != nil {
+		return err
+	}
+	registered, err := scaffold.RegisterService(ctx)
+	if err != nil {
+		return err
+	}
+	if !registered {
+		output.Printf("[EXISTS] %s is already registered in Program.cs\n", ctx.Service)
+	}
+
+	output.Printf("[SUCCESS] Added API %s (/api/%s)\n", name, name)
+	output.Println("[INFO] Run 'npm run generate' in the client to update the DTO types.")
+	return nil
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"strings"
+
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	serviceActions  string
+	apiHookQuery    bool
+	apiHookMutation bool
+)
+
+var makeComponentCmd = &cobra.Command{
+	Use:   "component <Name>",
+	Short: "Generate a React component exported from src/components",
+	Long: `Generate src/components/<name>.tsx and export it from src/components/index.ts.
+Folders in the name are kept: forms/TextField -> src/components/forms/text-field.tsx.`,
+	Example: `  poyo make component Card
+  poyo make component forms/TextField`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := scaffold.NewComponentContext(args[0])
+		if err != nil {
+			return output.Errorf(output.ErrInvalidArgument, "%v", err)
+		}
+		if err := scaffold.MakeComponent(ctx); err != nil {
+			return err
+		}
+		output.Printf("[SUCCESS] Added component %s\n", ctx.Name)
+		return nil
+	},
+}
+
+var makeHookCmd = &cobra.Command{
+	Use:   "hook <Name>",
+	Short: "Generate a React hook exported from src/hooks",
+	Long: `Generate src/hooks/use-<name>.ts and export it from src/hooks/index.ts.
+The "use" prefix is optional: Toggle, useToggle and use-toggle all give useToggle.`,
+	Example: `  poyo make hook useToggle`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := scaffold.NewHookContext(args[0])
+		if err != nil {
+			return output.Errorf(output.ErrInvalidArgument, "%v", err)
+		}
+		if err := scaffold.MakeHook(ctx); err != nil {
+			return err
+		}
+		output.Printf("[SUCCESS] Added hook %s\n", ctx.Hook)
+		return nil
+	},
+}
+
+var makeServiceCmd = &cobra.Command{
+	Use:   "service <Name>",
+	Short: "Generate an API service exported from src/services",
+	Long: `Generate src/services/<name>.service.ts calling the actions of an API made with
+'poyo make api', add their paths to ENDPOINTS in src/lib/api/endpoints.ts and export the
+service from src/services/index.ts. The request and response types are the generated DTOs
+(<Action><Name>Request, JSendResponseOf<Action><Name>Response).`,
+	Example: `  poyo make service Products --actions Get,List,Create,Update,Delete`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := naming.Pascal(args[0])
+		if name == "" {
+			return output.Errorf(output.ErrInvalidArgument, "invalid service name %q", args[0])
+		}
+		actions := splitActions(serviceActions)
+		if len(actions) == 0 {
+			return output.Errorf(output.ErrInvalidArgument, "--actions needs at least one action name")
+		}
+		ctx := scaffold.NewServiceContext(name, actions)
+		if err := scaffold.MakeService(ctx); err != nil {
+			return err
+		}
+		output.Printf("[SUCCESS] Added service %s\n", ctx.Service)
+		return nil
+	},
+}
+
+var makeAPIHookCmd = &cobra.Command{
+	Use:   "api-hook <Domain>/<Action>",
+	Short: "Generate a React Query hook for an API action in src/hooks-api",
+	Long: `Generate a hook calling <domain>Service.<action> in src/hooks-api/<domain>/queries or
+src/hooks-api/<domain>/mutations, exported through the barrels of its folder, its domain and
+src/hooks-api. Get/List/Search actions are queries (useQuery), others are mutations
+(useMutation); use --query or --mutation to choose.`,
+	Example: `  poyo make api-hook Products/List
+  poyo make api-hook Products/Create`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		segments := naming.Segments(args[0])
+		if len(segments) != 2 || naming.Pascal(segments[0]) == "" || naming.Pascal(segments[1]) == "" {
+			return output.Errorf(output.ErrInvalidArgument, "invalid API hook %q: use <Domain>/<Action>, e.g. Products/Create", args[0])
+		}
+		if apiHookQuery && apiHookMutation {
+			return output.Errorf(output.ErrInvalidArgument, "--query and --mutation cannot be used together")
+		}
+		ctx := scaffold.NewAPIHookContext(segments[0], segments[1])
+		query := ctx.IsQuery()
+		if apiHookQuery || apiHookMutation {
+			query = apiHookQuery
+		}
+		if err := scaffold.MakeAPIHook(ctx, query); err != nil {
+			return err
+		}
+		output.Printf("[SUCCESS] Added API hook %s\n", ctx.Hook)
+		return nil
+	},
+}
+
+func init() {
+	makeServiceCmd.Flags().StringVar(&serviceActions, "actions", "Get", "Comma separated action names")
+	makeAPIHookCmd.Flags().BoolVar(&apiHookQuery, "query", false, "Generate a query hook (useQuery)")
+	makeAPIHookCmd.Flags().BoolVar(&apiHookMutation, "mutation", false, "Generate a mutation hook (useMutation)")
+
+	makeCmd.AddCommand(makeComponentCmd, makeHookCmd, makeServiceCmd, makeAPIHookCmd)
+}
+
+// splitActions parses a comma separated list of action names, dropping blanks and duplicates.
+func splitActions(list string) []string {
+	var actions []string
+	seen := make(map[string]bool)
+	for _, a := range strings.Split(list, ",") {
+		a = naming.Pascal(strings.TrimSpace(a))
+		if a == "" || seen[a] {
+			continue
+		}
+		seen[a] = true
+		actions = append(actions, a)
+	}
+	return actions
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+	"poyo-cli/internal/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageDataFields     string
+	pageDataController string
+	pageDataAction     string
+)
+
+var makePageDataCmd = &cobra.Command{
+	Use:   "page-data <route>",
+	Short: "Generate typed server data for a page",
+	Long: `Generate the server data of a page, shared between C# and TypeScript:
+
+  Models/Pages/<Name>Data.cs   C# record
+  <Controller>.<Action>        serializes it into ViewBag.ServerData
+  <page>.data.ts               matching TS interface, read with usePage<NameData>()
+
+Routes served by PageController get a custom controller action (--controller/--action,
+by default <FirstSegment>Controller) and routes.json is updated.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMakePageData,
+
+	ValidArgsFunction: completeRoutePaths,
+}
+
+func init() {
+	makePageDataCmd.Flags().StringVar(&pageDataFields, "fields", "", "Properties as name:type (C# types), e.g. \"title:string,count:int,tags:List<string>\"")
+	makePageDataCmd.Flags().StringVarP(&pageDataController, "controller", "c", "", "Controller for routes without one")
+	makePageDataCmd.Flags().StringVarP(&pageDataAction, "action", "a", "", "Action for routes without one")
+	makePageDataCmd.RegisterFlagCompletionFunc("controller", completeControllers)
+
+	makeCmd.AddCommand(makePageDataCmd)
+}
+
+func runMakePageData(cmd *cobra.Command, args []string) error {
+	r, err := routes.Read(config.RoutesJSON)
+	if err != nil {
+		return err
+	}
+	idx := findRoute(r, args[0])
+	if idx == -1 {
+		return routeNotFound(r, args[0])
+	}
+	rt := &r[idx]
+	output.Route(rt.Path)
+
+	fields := scaffold.DefaultFields
+	if pageDataFields != "" {
+		if fields, err = scaffold.ParseFields(pageDataFields); err != nil {
+			return output.Errorf(output.ErrInvalidArgument, "%v", err)
+		}
+	}
+
+	// Server data is set by a controller action. ctxRoute keeps the folder of --controller Admin/Reports.
+	ctxRoute := *rt
+	if rt.Controller == "" {
+		controller, action := dataController(rt.Name)
+		if pageDataController != "" {
+			controller, action = pageDataController, pageDataAction
+			if action == "" {
+				return output.Errorf(output.ErrInvalidArgument, "if --controller is specified, --action must also be specified")
+			}
+		}
+		rt.Controller, rt.Action = controllers.ClassName(controller), action
+		ctxRoute.Controller, ctxRoute.Action = controller, action
+		if err := routes.Write(config.RoutesJSON, r); err != nil {
+			return err
+		}
+		output.Modified(config.RoutesJSON, "[UPDATE] %s is now served by %s.%s\n", rt.Path, rt.Controller, rt.Action)
+	}
+
+	ctx := scaffold.NewContext(ctxRoute).WithData(*rt, fields)
+	if err := scaffold.ScaffoldPageData(*rt, ctx); err != nil {
+		return err
+	}
+
+	// Controller action
+	file := filepath.Join(config.ServerDir, filepath.FromSlash(ctx.ControllerPath))
+	actions, _, err := controllers.ParseFile(file)
+	if _, found := controllers.Find(actions, rt.Action); err != nil || !found {
+		if _, err := scaffold.EnsureController(ctx); err != nil {
+			return err
+		}
+		output.Printf("[UPDATED] Controller: %s (Added action '%s')\n", filepath.Base(file), rt.Action)
+	} else {
+		changed, err := scaffold.InjectServerData(file, ctx)
+		if err != nil {
+			return err
+		}
+		if changed {
+			output.Modified(file, "[UPDATED] Controller: %s (%s sets ViewBag.ServerData)\n", filepath.Base(file), rt.Action)
+		} else {
+			output.Printf("[EXISTS] %s.%s already sets ViewBag.ServerData\n", rt.Controller, rt.Action)
+			output.Warn("%s.%s already sets ViewBag.ServerData; check it uses %s", rt.Controller, rt.Action, ctx.Data)
+		}
+	}
+
+	// Page
+	page := filepath.Join(config.ClientDir, rt.Files.React)
+	if _, err := os.Stat(page); err != nil {
+		output.Printf("[SKIP] React Page not found: %s\n", rt.Files.React)
+		output.Warn("React page not found: %s", rt.Files.React)
+	} else {
+		changed, err := scaffold.WirePage(page, ctx)
+		if err != nil {
+			return err
+		}
+		if changed {
+			output.Modified(page, "[UPDATED] React Page: %s (usePage<%s>())\n", rt.Files.React, ctx.Data)
+		}
+	}
+
+	output.Printf("[SUCCESS] %s has typed server data %s\n", rt.Path, ctx.Data)
+	return nil
+}
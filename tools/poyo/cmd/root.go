@@ -1,21 +1,116 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/tui"
+
 	"github.com/spf13/cobra"
 )
 
+var (
+	outputFormat string
+	rootDir      string
+	appName      string
+)
+
 var RootCmd = &cobra.Command{
 	Use:   "poyo",
 	Short: "Poyo CLI utility",
 	Long:  `Poyo CLI - A general purpose tool for managing Poyo projects.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.SetFormat(outputFormat); err != nil {
+			return err
+		}
+		output.SetCommand(cmd.CommandPath())
+		if err := loadConfig(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return output.Errorf(output.ErrInvalidArgument, "%v", err)
+		}
+		if err := naming.Load(); err != nil {
+			// A broken config is not a usage error
+			cmd.SilenceUsage = true
+			return output.Errorf(output.ErrInvalidArgument, "%v", err)
+		}
+		if output.IsJSON() {
+			// Errors are reported in the JSON result, prompts go to stderr
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			tui.Output = os.Stderr
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", output.Text, "Output format: text or json")
+	RootCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{output.Text, output.JSON}, cobra.ShellCompDirectiveNoFileComp))
+	RootCmd.PersistentFlags().StringVar(&rootDir, "root", "", "Project root (default: $"+config.RootEnv+", else the first folder up from here with routes.json or poyo.config.json)")
+	RootCmd.MarkPersistentFlagDirname("root")
+	RootCmd.PersistentFlags().StringVar(&appName, "app", "", "App of the workspace to work on (default: $"+config.AppEnv+", else the app containing the current folder)")
+	RootCmd.RegisterFlagCompletionFunc("app", completeApps)
+	RootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &output.CodedError{Code: output.ErrInvalidArgument, Err: err}
+	})
+}
+
+// loadConfig resolves the project layout for cmd. Completion needs no project, config commands
+// only need the root so that they can set the client or server project when it cannot be inferred,
+// and --all-apps works on every app of the workspace rather than a selected one.
+func loadConfig(cmd *cobra.Command) error {
+	switch cmd.Name() {
+	case "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return nil
+	}
+	err := config.Load(rootDir, appName)
+	var dirErr *config.DirError
+	if errors.As(err, &dirErr) && cmd.Parent() == configCmd {
+		output.Printf("[WARN] %v\n\n", err)
+		output.Warn("%v", err)
+		return nil
+	}
+	var appErr *config.AppError
+	if all, _ := cmd.Flags().GetBool("all-apps"); all && errors.As(err, &appErr) {
+		return nil
+	}
+	return err
 }
 
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
+	// Flag errors happen before PersistentPreRunE, so pick up --output early.
+	if jsonRequested(os.Args[1:]) {
+		output.SetFormat(output.JSON)
+		RootCmd.SilenceErrors = true
+		RootCmd.SilenceUsage = true
+		if c, _, err := RootCmd.Find(os.Args[1:]); err == nil {
+			output.SetCommand(c.CommandPath())
+		}
+	}
+
+	err := RootCmd.Execute()
+	if output.IsJSON() {
+		output.Flush(err)
+	} else if err != nil {
 		fmt.Fprintln(os.Stderr, err)
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
+
+func jsonRequested(args []string) bool {
+	for i, a := range args {
+		switch {
+		case a == "--output=json" || a == "-o=json" || a == "-ojson":
+			return true
+		case (a == "--output" || a == "-o") && i+1 < len(args) && args[i+1] == output.JSON:
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"strings"
+
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+
 	"github.com/spf13/cobra"
 )
 
@@ -12,3 +17,48 @@ var routeCmd = &cobra.Command{
 func init() {
 	RootCmd.AddCommand(routeCmd)
 }
+
+// findRoute returns the index of the route matching urlPath, ignoring case and a missing leading slash, or -1.
+func findRoute(r []routes.Route, urlPath string) int {
+	for i := range r {
+		if strings.EqualFold(r[i].Path, urlPath) || strings.EqualFold(r[i].Path, "/"+strings.TrimPrefix(urlPath, "/")) {
+			return i
+		}
+	}
+	return -1
+}
+
+// routeNotFound returns a ROUTE_NOT_FOUND error, suggesting the closest existing path.
+func routeNotFound(r []routes.Route, urlPath string) error {
+	want := strings.ToLower("/" + strings.TrimPrefix(urlPath, "/"))
+	best, bestDist := "", 4 // Only suggest close matches
+	for _, rt := range r {
+		if d := levenshtein(want, strings.ToLower(rt.Path)); d < bestDist {
+			best, bestDist = rt.Path, d
+		}
+	}
+	if best != "" {
+		return output.Errorf(output.ErrRouteNotFound, "route not found: %s (did you mean %s?)", urlPath, best)
+	}
+	return output.Errorf(output.ErrRouteNotFound, "route not found: %s", urlPath)
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
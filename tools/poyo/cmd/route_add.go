@@ -2,9 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"regexp"
 	"strings"
 
 	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
 	"poyo-cli/internal/routes"
 	"poyo-cli/internal/scaffold"
 
@@ -18,6 +24,13 @@ var (
 	addController string
 	addAction     string
 	addNoView     bool
+	addLayout     string
+	addName       string
+	addURLStyle   string
+	addTemplate   string
+	addData       bool
+	addFields     string
+	addWithTests  bool
 )
 
 var addCmd = &cobra.Command{
@@ -30,33 +43,81 @@ var addCmd = &cobra.Command{
 func init() {
 	addCmd.Flags().BoolVarP(&addPublic, "public", "p", false, "Mark route as public")
 	addCmd.Flags().BoolVarP(&addGuest, "guest", "g", false, "Mark route as guest only")
-	addCmd.Flags().BoolVarP(&addFlat, "flat", "f", false, "Use flat file structure")
+	addCmd.Flags().BoolVarP(&addFlat, "flat", "f", false, "Use flat file structure (default from poyo.config.json)")
 	addCmd.Flags().StringVarP(&addController, "controller", "c", "", "Controller name")
 	addCmd.Flags().StringVarP(&addAction, "action", "a", "", "Action name")
-	addCmd.Flags().BoolVar(&addNoView, "no-view", false, "Skip MVC View generation")
+	addCmd.Flags().BoolVar(&addNoView, "no-view", false, "Skip MVC View generation (default from poyo.config.json)")
+	addCmd.Flags().StringVar(&addLayout, "layout", "", "Razor layout for the generated view (from Views/Shared, default from poyo.config.json)")
+	addCmd.Flags().StringVar(&addName, "name", "", "Override the route name used for files and components (e.g. Admin/APIKeys)")
+	addCmd.Flags().StringVar(&addURLStyle, "url-style", "", "Route path style: pascal, kebab or preserve (default from poyo.config.json)")
+	addCmd.Flags().StringVarP(&addTemplate, "template", "t", "", "Page template: blank, list, form, detail or a project template (see 'poyo template list', default from poyo.config.json)")
+	addCmd.Flags().BoolVar(&addData, "data", false, "Generate typed server data (C# record, controller action setting ViewBag.ServerData, TS interface)")
+	addCmd.Flags().StringVar(&addFields, "fields", "", "Server data properties as name:type (C# types), e.g. \"title:string,count:int\". Implies --data")
+	addCmd.Flags().BoolVar(&addWithTests, "with-tests", false, "Generate a Vitest page test and, with --controller, an xUnit test (default from poyo.config.json)")
+	addCmd.RegisterFlagCompletionFunc("controller", completeControllers)
+	addCmd.RegisterFlagCompletionFunc("template", completePageVariants)
+	addCmd.RegisterFlagCompletionFunc("url-style", completeURLStyles)
+	addCmd.RegisterFlagCompletionFunc("layout", completeLayouts)
 
 	routeCmd.AddCommand(addCmd)
 }
 
+var routeParamRe = regexp.MustCompile(`\{[^}]*\}`)
+
 func runAdd(cmd *cobra.Command, args []string) error {
 	urlPath := args[0]
 
 	// Guard: Detect if shell transformed /Path to C:/Program Files/Git/Path
-	if strings.Contains(urlPath, ":") {
-		return fmt.Errorf("invalid path detected '%s'.\n\nIf you are using Git Bash, it automatically converts paths matching root directories.\nPlease use a double slash to escape it: //User/Profile\nOr use a relative path: User/Profile", urlPath)
+	// (route constraints such as {id:int} are fine)
+	if strings.Contains(routeParamRe.ReplaceAllString(urlPath, ""), ":") {
+		return output.Errorf(output.ErrInvalidPath, "invalid path detected '%s'.\n\nIf you are using Git Bash, it automatically converts paths matching root directories.\nPlease use a double slash to escape it: //User/Profile\nOr use a relative path: User/Profile", urlPath)
 	}
 
-	// Normalize path: /foo/bar -> /Foo/Bar (PascalCase)
-	parts := strings.Split(urlPath, "/")
-	var pascalParts []string
-	for _, p := range parts {
-		if p == "" {
-			continue
+	// Flags that are not given default to the routeAdd section of poyo.config.json
+	p, err := config.LoadProject()
+	if err != nil {
+		return err
+	}
+	defaults := p.RouteAdd
+	if !cmd.Flags().Changed("flat") {
+		addFlat = defaults.Flat
+	}
+	if !cmd.Flags().Changed("no-view") {
+		addNoView = defaults.NoView
+	}
+	if !cmd.Flags().Changed("layout") {
+		addLayout = defaults.Layout
+	}
+	if !cmd.Flags().Changed("template") {
+		addTemplate = defaults.Template
+	}
+	if !cmd.Flags().Changed("with-tests") {
+		addWithTests = defaults.WithTests
+	}
+
+	// Normalize path and name with the project naming strategy:
+	// /user-profile -> path /UserProfile (or /user-profile for kebab URLs), name UserProfile
+	segments := naming.Segments(urlPath)
+	if len(segments) == 0 {
+		return output.Errorf(output.ErrInvalidPath, "invalid path '%s': at least one segment is required", urlPath)
+	}
+	if addTemplate != "" && !scaffold.HasPageVariant(addTemplate) {
+		return output.Errorf(output.ErrInvalidArgument, "unknown page template %q (available: %s)", addTemplate, strings.Join(scaffold.PageVariants(), ", "))
+	}
+
+	strategy := naming.Current()
+	if addURLStyle != "" {
+		style, err := naming.ParseURLStyle(addURLStyle)
+		if err != nil {
+			return output.Errorf(output.ErrInvalidArgument, "%v", err)
 		}
-		pascalParts = append(pascalParts, strings.Title(strings.ToLower(p)))
+		strategy.URL = style
+	}
+	routePath := strategy.Path(segments)
+	name := strategy.Name(segments)
+	if addName != "" {
+		name = strings.Join(naming.Segments(addName), "/")
 	}
-	pascalPath := "/" + strings.Join(pascalParts, "/")
-	name := strings.Join(pascalParts, "/")
 
 	// Check if exists
 	r, err := routes.Read(config.RoutesJSON)
@@ -65,18 +126,38 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	for _, rt := range r {
-		if strings.EqualFold(rt.Path, pascalPath) {
-			return fmt.Errorf("route already exists: %s", pascalPath)
+		if strings.EqualFold(rt.Path, routePath) {
+			return output.Errorf(output.ErrRouteExists, "route already exists: %s", routePath)
+		}
+		if rt.Name == name {
+			return output.Errorf(output.ErrRouteExists, "route name %s is already used by %s (use --name to pick another)", name, rt.Path)
 		}
 	}
 
 	files := routes.ResolvePaths(name, addFlat)
 
+	var fields []scaffold.Field
+	if addData || addFields != "" {
+		fields = scaffold.DefaultFields
+		if addFields != "" {
+			if fields, err = scaffold.ParseFields(addFields); err != nil {
+				return output.Errorf(output.ErrInvalidArgument, "%v", err)
+			}
+		}
+		// Server data is set by a controller action
+		if addController == "" {
+			addController, addAction = dataController(name)
+		}
+	}
+
 	// Controller Logic
 	var controllerInfo *scaffold.ControllerInfo
 	if addController != "" {
 		if addAction == "" {
-			return fmt.Errorf("if --controller is specified, --action must also be specified")
+			return output.Errorf(output.ErrInvalidArgument, "if --controller is specified, --action must also be specified")
+		}
+		if err := checkControllerFolder(addController); err != nil {
+			return err
 		}
 		controllerInfo = &scaffold.ControllerInfo{
 			Name:   addController,
@@ -86,7 +167,7 @@ func runAdd(cmd *cobra.Command, args []string) error {
 
 	// Create Route Struct
 	newRoute := routes.Route{
-		Path:        pascalPath,
+		Path:        routePath,
 		Name:        name,
 		Files:       files,
 		IsPublic:    addPublic,
@@ -108,12 +189,11 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	// But scaffold logic for controller returns the "Safe" Controller Name.
 	
 	if controllerInfo != nil {
-		safeName, err := scaffold.EnsureController(
-			config.ControllersDir,
-			controllerInfo.Name,
-			controllerInfo.Action,
-			files.View,
-		)
+		ctx := scaffold.NewContext(newRoute)
+		if fields != nil {
+			ctx = ctx.WithData(newRoute, fields)
+		}
+		safeName, err := scaffold.EnsureController(ctx)
 		if err != nil && err.Error() != "action already exists" {
 			return err
 		}
@@ -123,15 +203,19 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		// Wait, EnsureController returns `name` (argument) or updated.
 		// My implementation of EnsureController returns just `name` or `name+"Controller"`.
 		// Let's verify EnsureController returns.
-		newRoute.Controller = safeName
+		if safeName != "" {
+			newRoute.Controller = safeName
+		}
 	}
 
 	r = append(r, newRoute)
 	if err := routes.Write(config.RoutesJSON, r); err != nil {
 		return err
 	}
+	output.Route(routePath)
+	output.Modified(config.RoutesJSON, "")
 	
-	opt := scaffold.ScaffoldOptions{NoView: addNoView}
+	opt := scaffold.ScaffoldOptions{NoView: addNoView, Layout: addLayout, Template: addTemplate, Fields: fields, WithTests: addWithTests}
 	// We pass nil for controller here because we arguably already handled it above for the Route struct?
 	// But ScaffoldRouteFiles ALSO calls EnsureController?
 	// My ScaffoldRouteFiles calls EnsureController if controllerInfo is passed.
@@ -158,10 +242,41 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	// The problem is updating the JSON with the correct controller name (e.g. adding "Controller" suffix).
 	// I will keep the explicit EnsureController call here to get the name, and pass nil to ScaffoldRouteFiles for controller to avoid double log/work.
 	
-	if err := scaffold.ScaffoldRouteFiles(name, files, opt, nil); err != nil {
+	if err := scaffold.ScaffoldRouteFiles(newRoute, opt, nil); err != nil {
 		return err
 	}
 	
-	fmt.Printf("[SUCCESS] Added route %s\n", pascalPath)
+	output.Printf("[SUCCESS] Added route %s\n", routePath)
+	return nil
+}
+
+// dataController picks the controller action serving a page with server data:
+// "Orders" -> OrdersController.Index, "Orders/Edit" -> OrdersController.Edit.
+func dataController(name string) (string, string) {
+	segments := naming.Segments(name)
+	if len(segments) == 1 {
+		return segments[0], "Index"
+	}
+	return segments[0], strings.Join(segments[1:], "")
+}
+
+// checkControllerFolder validates the folder of --controller Admin/Reports. A controller class of
+// the same name elsewhere is refused: MVC routes controllers by class name, so both would clash.
+func checkControllerFolder(name string) error {
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		return nil
+	}
+	for _, folder := range strings.Split(name[:i], "/") {
+		if folder == "" || folder == "." || folder == ".." || strings.ContainsAny(folder, `\:`) {
+			return output.Errorf(output.ErrInvalidPath, "invalid controller folder %q", name[:i])
+		}
+	}
+	want := controllers.Path(config.ControllersDir, name)
+	if existing := controllers.Path(config.ControllersDir, controllers.ClassName(name)); existing != want {
+		if _, err := os.Stat(existing); err == nil {
+			return output.Errorf(output.ErrInvalidArgument, "%s already exists in %s", controllers.ClassName(name), fsutil.RelToRoot(existing))
+		}
+	}
 	return nil
 }
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/gitutil"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [<rev|file>] [<rev|file>]",
+	Short: "Show the semantic difference of routes.json between git revisions or files",
+	Long: `Show what changed in routes.json in terms of routes, access levels and SEO.
+
+  poyo route diff                 HEAD vs working tree
+  poyo route diff main            main vs working tree
+  poyo route diff main HEAD       main vs HEAD
+  poyo route diff a.json b.json   two files`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	routeCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	from, to := "HEAD", ""
+	if len(args) > 0 {
+		from = args[0]
+	}
+	if len(args) > 1 {
+		to = args[1]
+	}
+
+	oldRoutes, err := loadRoutesAt(from)
+	if err != nil {
+		return err
+	}
+	newRoutes, err := loadRoutesAt(to)
+	if err != nil {
+		return err
+	}
+
+	changes := routes.Diff(oldRoutes, newRoutes)
+	if changes == nil {
+		changes = []routes.Change{}
+	}
+	output.Data("changes", changes)
+
+	if len(changes) == 0 {
+		output.Println("[INFO] No route changes.")
+		return nil
+	}
+	for _, c := range changes {
+		output.Printf("%s\n", c)
+	}
+	return nil
+}
+
+// loadRoutesAt reads routes from a file path, a git revision, or the working tree when spec is empty.
+func loadRoutesAt(spec string) ([]routes.Route, error) {
+	if spec == "" {
+		return routes.Read(config.RoutesJSON)
+	}
+	if info, err := os.Stat(spec); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(spec)
+		if err != nil {
+			return nil, err
+		}
+		return routes.Parse(data)
+	}
+
+	if !gitutil.Available(config.RootDir) {
+		return nil, output.Errorf(output.ErrInvalidArgument, "%q is not a file and no git repository was found", spec)
+	}
+	rel, err := filepath.Rel(config.RootDir, config.RoutesJSON)
+	if err != nil {
+		return nil, err
+	}
+	data, err := gitutil.Show(config.RootDir, spec, rel)
+	if err != nil {
+		return nil, output.Errorf(output.ErrInvalidArgument, "cannot read routes.json at %s: %v", spec, err)
+	}
+	return routes.Parse(data)
+}
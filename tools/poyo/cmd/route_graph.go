@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/graph"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export pages, controllers, access levels and redirects as a diagram",
+	Args:  cobra.NoArgs,
+	RunE:  runGraph,
+}
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "mermaid", "Diagram format: mermaid, dot or json")
+	graphCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"mermaid", "dot", "json"}, cobra.ShellCompDirectiveNoFileComp))
+
+	routeCmd.AddCommand(graphCmd)
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	r, err := routes.Read(config.RoutesJSON)
+	if err != nil {
+		return err
+	}
+	files, err := controllers.Scan(config.ControllersDir)
+	if err != nil {
+		return err
+	}
+
+	g := graph.Build(r, files, graph.DiscoverAuthRedirects(config.ServerDir))
+
+	var rendered string
+	switch graphFormat {
+	case "mermaid":
+		rendered = graph.Mermaid(g)
+	case "dot":
+		rendered = graph.DOT(g)
+	case "json":
+		data, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return err
+		}
+		rendered = string(data) + "\n"
+	default:
+		return output.Errorf(output.ErrInvalidArgument, "unknown graph format %q (expected mermaid, dot or json)", graphFormat)
+	}
+
+	output.Data("graph", g)
+	if graphFormat != "json" {
+		output.Data("diagram", rendered)
+	}
+	output.Printf("%s", rendered)
+	return nil
+}
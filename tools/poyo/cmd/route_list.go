@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+
+	"github.com/spf13/cobra"
+)
+
+var listAllApps bool
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the routes of routes.json",
+	Long: `List the routes of routes.json with their name, access level and controller action.
+
+In a workspace, --all-apps lists the routes of every app.`,
+	Example: `  poyo route list
+  poyo route list --app admin
+  poyo route list --all-apps -o json`,
+	Args: cobra.NoArgs,
+	RunE: runList,
+}
+
+func init() {
+	listCmd.Flags().BoolVar(&listAllApps, "all-apps", false, "List the routes of every app of the workspace")
+
+	routeCmd.AddCommand(listCmd)
+}
+
+// listedRoute is a row of route list.
+type listedRoute struct {
+	App        string `json:"app,omitempty"`
+	Path       string `json:"path"`
+	Name       string `json:"name"`
+	Access     string `json:"access"`
+	Controller string `json:"controller,omitempty"`
+	Action     string `json:"action,omitempty"`
+	React      string `json:"react"`
+	View       string `json:"view,omitempty"`
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	if listAllApps && appName != "" {
+		return output.Errorf(output.ErrInvalidArgument, "--all-apps and --app cannot be used together")
+	}
+
+	apps := []string{config.AppName}
+	if listAllApps && len(config.Apps) > 0 {
+		apps = config.AppNames()
+	}
+
+	var list []listedRoute
+	for _, app := range apps {
+		if app != config.AppName {
+			if err := config.Load(rootDir, app); err != nil {
+				return fmt.Errorf("app %s: %w", app, err)
+			}
+		}
+		r, err := routes.Read(config.RoutesJSON)
+		if err != nil {
+			return fmt.Errorf("%s: %w", config.RoutesJSON, err)
+		}
+		for _, rt := range r {
+			list = append(list, listedRoute{
+				App:        app,
+				Path:       rt.Path,
+				Name:       rt.Name,
+				Access:     routes.Access(rt),
+				Controller: rt.Controller,
+				Action:     rt.Action,
+				React:      rt.Files.React,
+				View:       rt.Files.View,
+			})
+		}
+	}
+	output.Data("routes", list)
+
+	if len(list) == 0 {
+		output.Println("No routes.")
+		return nil
+	}
+
+	rows := [][]string{{"PATH", "NAME", "ACCESS", "ACTION"}}
+	if len(apps) > 1 || config.AppName != "" {
+		rows[0] = append([]string{"APP"}, rows[0]...)
+	}
+	for _, l := range list {
+		action := "PageController"
+		if l.Controller != "" {
+			action = l.Controller + "." + l.Action
+		}
+		row := []string{l.Path, l.Name, l.Access, action}
+		if len(rows[0]) > 4 {
+			row = append([]string{l.App}, row...)
+		}
+		rows = append(rows, row)
+	}
+	printTable(rows)
+	return nil
+}
+
+// printTable prints rows as left-aligned columns, the first row being the header.
+func printTable(rows [][]string) {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	for _, row := range rows {
+		var b strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				b.WriteString(cell)
+				break
+			}
+			fmt.Fprintf(&b, "%-*s  ", widths[i], cell)
+		}
+		output.Println(b.String())
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateURLStyle   string
+	migrateDryRun     bool
+	migrateNoRedirect bool
+)
+
+var migrateURLsCmd = &cobra.Command{
+	Use:   "migrate-urls",
+	Short: "Rewrite route paths to a URL style and redirect the old paths",
+	Long: `Rewrite every route path in routes.json to the given URL style (e.g. /UserSettings -> /user-settings).
+
+Route names, files and components are not renamed. Each old path is added to the route's
+"redirects", which the server answers with a permanent redirect. The style is saved in
+poyo.config.json so that new routes follow it.`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateURLs,
+}
+
+func init() {
+	migrateURLsCmd.Flags().StringVar(&migrateURLStyle, "url-style", "", "Target path style: pascal, kebab or preserve (default from poyo.config.json)")
+	migrateURLsCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Show the new paths without writing anything")
+	migrateURLsCmd.Flags().BoolVar(&migrateNoRedirect, "no-redirects", false, "Do not redirect the old paths")
+	migrateURLsCmd.RegisterFlagCompletionFunc("url-style", completeURLStyles)
+
+	routeCmd.AddCommand(migrateURLsCmd)
+}
+
+func runMigrateURLs(cmd *cobra.Command, args []string) error {
+	strategy := naming.Current()
+	if migrateURLStyle != "" {
+		style, err := naming.ParseURLStyle(migrateURLStyle)
+		if err != nil {
+			return output.Errorf(output.ErrInvalidArgument, "%v", err)
+		}
+		strategy.URL = style
+	}
+
+	r, err := routes.Read(config.RoutesJSON)
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for i := range r {
+		rt := &r[i]
+		segments := naming.Segments(rt.Path)
+		if len(segments) == 0 {
+			continue
+		}
+		newPath := strategy.Path(segments)
+		if newPath == rt.Path {
+			continue
+		}
+		if other := findPath(r, newPath, i); other != nil {
+			output.Printf("[SKIP] %s: %s is already used by %s\n", rt.Path, newPath, other.Name)
+			output.Warn("%s not migrated: %s is already used by %s", rt.Path, newPath, other.Name)
+			continue
+		}
+
+		oldPath := rt.Path
+		rt.Path = newPath
+		rt.Redirects = removeFold(rt.Redirects, newPath)
+		// Routing is case-insensitive, so a case-only change needs no redirect (it would loop)
+		if !migrateNoRedirect && !strings.EqualFold(oldPath, newPath) {
+			rt.Redirects = appendFold(rt.Redirects, oldPath)
+		}
+
+		output.Route(newPath)
+		output.Printf("[MIGRATE] %s -> %s\n", oldPath, newPath)
+		migrated++
+	}
+
+	if migrateDryRun {
+		output.Printf("[INFO] Dry run: %d route(s) would be migrated.\n", migrated)
+		return nil
+	}
+
+	if migrated > 0 {
+		if err := routes.Write(config.RoutesJSON, r); err != nil {
+			return err
+		}
+		output.Modified(config.RoutesJSON, "")
+	}
+
+	// Remember the style so that route add follows it
+	p, err := config.LoadProject()
+	if err != nil {
+		return err
+	}
+	if p.Naming.URL != string(strategy.URL) {
+		p.Naming.URL = string(strategy.URL)
+		if err := config.SaveProject(p); err != nil {
+			return err
+		}
+		output.Modified(config.ProjectFile, "[UPDATE] Set naming.url to %s in poyo.config.json\n", strategy.URL)
+	}
+
+	output.Printf("[SUCCESS] Migrated %d route(s).\n", migrated)
+	return nil
+}
+
+// findPath returns the route other than r[skip] whose path matches path, ignoring case.
+func findPath(r []routes.Route, path string, skip int) *routes.Route {
+	for i := range r {
+		if i != skip && strings.EqualFold(r[i].Path, path) {
+			return &r[i]
+		}
+	}
+	return nil
+}
+
+func appendFold(list []string, v string) []string {
+	for _, e := range list {
+		if strings.EqualFold(e, v) {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+func removeFold(list []string, v string) []string {
+	var out []string
+	for _, e := range list {
+		if !strings.EqualFold(e, v) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
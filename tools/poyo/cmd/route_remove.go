@@ -7,7 +7,9 @@ import (
 	"strings"
 
 	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
 	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/output"
 	"poyo-cli/internal/routes"
 	"poyo-cli/internal/tui"
 
@@ -19,6 +21,8 @@ var removeCmd = &cobra.Command{
 	Short: "Remove a route",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runRemove,
+
+	ValidArgsFunction: completeRoutePaths,
 }
 
 func init() {
@@ -41,22 +45,41 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	if idx == -1 {
-		return fmt.Errorf("route not found: %s", urlPath)
+		return routeNotFound(r, urlPath)
 	}
 
 	routeToRemove := r[idx]
 	controller := routeToRemove.Controller
-	
-	deleteController := false
-	if controller != "" {
-		cPath := filepath.Join(config.ControllersDir, controller+".cs")
-		if _, err := os.Stat(cPath); err == nil {
-			q := fmt.Sprintf("Route uses custom controller '%s'. Delete this controller file?", controller)
-			confirmed, err := tui.Confirm(q)
-			if err != nil {
-				return err
+
+	// Only the action serving this route is removed; the controller may serve other routes
+	var cPath string
+	var sharing []string
+	deleteAction := false
+	if controller != "" && routeToRemove.Action != "" {
+		cPath = controllers.Path(config.ControllersDir, controller)
+		actionShared := false
+		for i, other := range r {
+			if i == idx || other.Controller == "" || !strings.EqualFold(controllers.ClassName(other.Controller), controllers.ClassName(controller)) {
+				continue
+			}
+			sharing = append(sharing, other.Path)
+			if strings.EqualFold(other.Action, routeToRemove.Action) {
+				actionShared = true
+			}
+		}
+
+		actions, _, err := controllers.ParseFile(cPath)
+		if _, found := controllers.Find(actions, routeToRemove.Action); err == nil && found {
+			if actionShared {
+				output.Printf("[INFO] %s.%s also serves another route and is kept.\n", controller, routeToRemove.Action)
+			} else {
+				q := fmt.Sprintf("Route uses custom controller '%s'. Delete its action '%s'?", controller, routeToRemove.Action)
+				confirmed, err := tui.Confirm(q)
+				if err != nil {
+					return err
+				}
+				deleteAction = confirmed
 			}
-			deleteController = confirmed
 		}
 	}
 
@@ -67,10 +90,32 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	// Logic Execution
-	if deleteController {
-		cPath := filepath.Join(config.ControllersDir, controller+".cs")
-		os.Remove(cPath)
-		fmt.Printf("[DELETED] Controller: %s.cs\n", controller)
+	if deleteAction {
+		left, err := controllers.RemoveAction(cPath, routeToRemove.Action)
+		if err != nil {
+			return err
+		}
+		// The file goes only if nothing written by hand is left in it
+		_, content, err := controllers.ParseFile(cPath)
+		if err != nil {
+			return err
+		}
+		if left == 0 && controllers.Empty(content) {
+			if err := os.Remove(cPath); err != nil {
+				return err
+			}
+			output.Deleted(cPath, "[DELETED] Controller: %s (no actions left)\n", fsutil.RelToRoot(cPath))
+			fsutil.DeleteEmptyParents(cPath, config.ControllersDir)
+		} else {
+			output.Modified(cPath, "[DELETED] Action %s.%s\n", controller, routeToRemove.Action)
+			if left == 0 {
+				output.Printf("[INFO] %s has no actions left but is kept: it holds other code.\n", fsutil.RelToRoot(cPath))
+			}
+		}
+	}
+	if len(sharing) > 0 {
+		output.Printf("[WARN] Other routes still use %s: %s\n", controller, strings.Join(sharing, ", "))
+		output.Warn("other routes still use %s: %s", controller, strings.Join(sharing, ", "))
 	}
 
 	if deleteFiles {
@@ -79,13 +124,13 @@ func runRemove(cmd *cobra.Command, args []string) error {
 
 		if _, err := os.Stat(reactPath); err == nil {
 			os.Remove(reactPath)
-			fmt.Printf("[DELETED] React Page: %s\n", routeToRemove.Files.React)
+			output.Deleted(reactPath, "[DELETED] React Page: %s\n", routeToRemove.Files.React)
 			fsutil.DeleteEmptyParents(reactPath, config.ClientDir)
 		}
 
 		if _, err := os.Stat(viewPath); err == nil {
 			os.Remove(viewPath)
-			fmt.Printf("[DELETED] MVC View: %s\n", routeToRemove.Files.View)
+			output.Deleted(viewPath, "[DELETED] MVC View: %s\n", routeToRemove.Files.View)
 			fsutil.DeleteEmptyParents(viewPath, config.ServerDir)
 		}
 	}
@@ -96,12 +141,14 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("[REMOVED] Route '%s' removed from routes.json\n", routeToRemove.Path)
+	output.Route(routeToRemove.Path)
+	output.Modified(config.RoutesJSON, "[REMOVED] Route '%s' removed from routes.json\n", routeToRemove.Path)
 
 	if !deleteFiles {
-		fmt.Println("[INFO] Orphaned files (not deleted):")
-		fmt.Printf("  - poyo.client/%s\n", routeToRemove.Files.React)
-		fmt.Printf("  - Poyo.Server/%s\n", routeToRemove.Files.View)
+		output.Println("[INFO] Orphaned files (not deleted):")
+		output.Printf("  - poyo.client/%s\n", routeToRemove.Files.React)
+		output.Printf("  - Poyo.Server/%s\n", routeToRemove.Files.View)
+		output.Warn("orphaned files left on disk: %s, %s", routeToRemove.Files.React, routeToRemove.Files.View)
 	}
 
 	return nil
@@ -6,8 +6,13 @@ import (
 	"path/filepath"
 	"strings"
 
+	"poyo-cli/internal/check"
 	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/editorconfig"
 	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/relocate"
 	"poyo-cli/internal/routes"
 	"poyo-cli/internal/scaffold"
 	"poyo-cli/internal/tui"
@@ -26,95 +31,96 @@ func init() {
 }
 
 func runSync(cmd *cobra.Command, args []string) error {
-	fmt.Println("Checking route consistency...")
+	output.Println("Checking route consistency...")
 	r, err := routes.Read(config.RoutesJSON)
 	if err != nil {
 		return err
 	}
 
-	// 1. Forward Sync: Check missing files
-	type MissingRoute struct {
-		Route        routes.Route
-		MissingFiles []string
-	}
-	var missingRoutes []MissingRoute
-
-	for _, rt := range r {
-		reactFullPath := filepath.Join(config.ClientDir, rt.Files.React)
-		viewFullPath := filepath.Join(config.ServerDir, rt.Files.View)
-		missing := []string{}
+	missingRoutes, untrackedReact, untrackedViews := scanRoutes(r)
 
-		if _, err := os.Stat(reactFullPath); os.IsNotExist(err) {
-			missing = append(missing, "React Page")
-		}
-		if _, err := os.Stat(viewFullPath); os.IsNotExist(err) {
-			missing = append(missing, "MVC View")
-		}
-
-		if len(missing) > 0 {
-			missingRoutes = append(missingRoutes, MissingRoute{Route: rt, MissingFiles: missing})
+	// 3. Move Detection: Pair missing files with untracked ones (renamed or moved pages)
+	var missingFiles []relocate.Missing
+	for _, m := range missingRoutes {
+		for _, f := range m.MissingFiles {
+			kind := relocate.React
+			if f == "MVC View" {
+				kind = relocate.View
+			}
+			missingFiles = append(missingFiles, relocate.Missing{Route: m.Route, Kind: kind})
 		}
 	}
-
-	// 2. Reverse Sync: Check untracked files
-	// React Pages
-	reactPages, _ := fsutil.FindFiles(
-		filepath.Join(config.ClientDir, "src", "pages"),
-		func(path string) bool { return strings.HasSuffix(path, ".page.tsx") },
-		config.ClientDir,
-	)
-	// Views
-	viewPages, _ := fsutil.FindFiles(
-		filepath.Join(config.ServerDir, "Views"),
-		func(path string) bool {
-			name := filepath.Base(path)
-			return strings.HasSuffix(path, ".cshtml") && !strings.Contains(path, "Shared") && !strings.HasPrefix(name, "_")
-		},
-		config.ServerDir,
-	)
-
-	// Comparison Sets
-	trackedReact := make(map[string]bool)
-	trackedView := make(map[string]bool)
-	for _, rt := range r {
-		trackedReact[filepath.ToSlash(rt.Files.React)] = true
-		trackedView[filepath.ToSlash(rt.Files.View)] = true
-	}
-
-	var untrackedReact []string
-	for _, f := range reactPages {
-		if !trackedReact[filepath.ToSlash(f)] {
-			untrackedReact = append(untrackedReact, f)
+	moves := relocate.Detect(missingFiles, untrackedReact, untrackedViews)
+
+	// 4. Deep Checks: data-page-name, ViewBag.Title and controller views
+	contentIssues := check.Content(r)
+	var fixable []check.Issue
+	for _, issue := range contentIssues {
+		if issue.Fixable {
+			fixable = append(fixable, issue)
 		}
 	}
-	var untrackedViews []string
-	for _, f := range viewPages {
-		if !trackedView[filepath.ToSlash(f)] {
-			untrackedViews = append(untrackedViews, f)
-		}
+
+	// 5. Orphaned Controller Actions: views served by custom controllers that routes.json no longer uses
+	orphans, err := check.Orphans(r)
+	if err != nil {
+		return err
 	}
 
-	hasIssues := len(missingRoutes) > 0 || len(untrackedReact) > 0 || len(untrackedViews) > 0
+	hasIssues := len(missingRoutes) > 0 || len(untrackedReact) > 0 || len(untrackedViews) > 0 || len(contentIssues) > 0 || len(orphans) > 0
 
 	if !hasIssues {
-		fmt.Printf("[OK] All %d routes indicate valid files, and no untracked files found.\n", len(r))
+		output.Printf("[OK] All %d routes indicate valid files, and no untracked files found.\n", len(r))
 		return nil
 	}
 
-	fmt.Println("[WARN] Discrepancies found:")
+	output.Println("[WARN] Discrepancies found:")
 	if len(missingRoutes) > 0 {
-		fmt.Printf("  - %d routes have missing files.\n", len(missingRoutes))
+		output.Printf("  - %d routes have missing files.\n", len(missingRoutes))
+		for _, m := range missingRoutes {
+			output.Warn("%s: missing %s", m.Route.Path, strings.Join(m.MissingFiles, ", "))
+		}
 	}
 	if len(untrackedReact) > 0 {
-		fmt.Printf("  - %d untracked React pages found.\n", len(untrackedReact))
+		output.Printf("  - %d untracked React pages found.\n", len(untrackedReact))
+		for _, f := range untrackedReact {
+			output.Warn("untracked React page: %s", f)
+		}
 	}
 	if len(untrackedViews) > 0 {
-		fmt.Printf("  - %d untracked MVC views found.\n", len(untrackedViews))
+		output.Printf("  - %d untracked MVC views found.\n", len(untrackedViews))
+		for _, f := range untrackedViews {
+			output.Warn("untracked MVC view: %s", f)
+		}
+	}
+	if len(moves) > 0 {
+		output.Printf("  - %d files look moved or renamed:\n", len(moves))
+		for _, mv := range moves {
+			output.Printf("      %s: %s -> %s\n", mv.Route.Path, mv.From, mv.To)
+			output.Warn("%s: %s looks moved to %s", mv.Route.Path, mv.From, mv.To)
+		}
 	}
-	fmt.Println("")
+	if len(contentIssues) > 0 {
+		output.Printf("  - %d content inconsistencies found:\n", len(contentIssues))
+		for _, issue := range contentIssues {
+			output.Printf("      %s\n", issue)
+			output.Warn("%s", issue)
+		}
+	}
+	if len(orphans) > 0 {
+		output.Printf("  - %d orphaned controller actions found:\n", len(orphans))
+		for _, o := range orphans {
+			output.Printf("      %s\n", o)
+			output.Warn("orphaned action %s", o)
+		}
+	}
+	output.Println("")
 
 	// Choices
 	choices := []tui.Choice{}
+	if len(moves) > 0 {
+		choices = append(choices, tui.Choice{Name: "Relocate: Update routes to the new file locations", Value: "relocate"})
+	}
 	if len(missingRoutes) > 0 {
 		choices = append(choices, tui.Choice{Name: "Rescaffold: Re-create missing files for broken routes", Value: "rescaffold"})
 		choices = append(choices, tui.Choice{Name: "Prune: Remove broken routes from routes.json", Value: "prune"})
@@ -122,6 +128,13 @@ func runSync(cmd *cobra.Command, args []string) error {
 	if len(untrackedReact) > 0 || len(untrackedViews) > 0 {
 		choices = append(choices, tui.Choice{Name: "Add: Add untracked files to routes.json", Value: "add_untracked"})
 		choices = append(choices, tui.Choice{Name: "Delete: Delete untracked files from disk", Value: "delete_untracked"})
+		choices = append(choices, tui.Choice{Name: "Ignore permanently: Add untracked files to .poyoignore", Value: "ignore_untracked"})
+	}
+	if len(fixable) > 0 {
+		choices = append(choices, tui.Choice{Name: "Fix: Rewrite view attributes and controller view paths to match routes.json", Value: "fix_content"})
+	}
+	if len(orphans) > 0 {
+		choices = append(choices, tui.Choice{Name: "Clean: Remove orphaned controller actions", Value: "remove_orphans"})
 	}
 	choices = append(choices, tui.Choice{Name: "Ignore: Do nothing for now", Value: "ignore"})
 
@@ -131,27 +144,70 @@ func runSync(cmd *cobra.Command, args []string) error {
 	}
 
 	switch choice {
+	case "relocate":
+		checkboxChoices := []tui.Choice{}
+		for i, mv := range moves {
+			checkboxChoices = append(checkboxChoices, tui.Choice{
+				Name:  fmt.Sprintf("%s: %s -> %s (%.0f%% match)", mv.Route.Path, mv.From, mv.To, mv.Score*100),
+				Value: fmt.Sprintf("%d", i),
+			})
+		}
+
+		selectedIndicesStr, err := tui.Checkbox("Select routes to update to the new location:", checkboxChoices)
+		if err != nil {
+			return err
+		}
+
+		var selected []relocate.Move
+		for _, idxStr := range selectedIndicesStr {
+			var idx int
+			fmt.Sscanf(idxStr, "%d", &idx)
+			selected = append(selected, moves[idx])
+		}
+
+		if len(selected) > 0 {
+			relocate.Apply(r, selected)
+			if err := routes.Write(config.RoutesJSON, r); err != nil {
+				return err
+			}
+			output.Modified(config.RoutesJSON, "")
+			for _, mv := range selected {
+				output.Route(mv.Route.Path)
+				output.Printf("[UPDATED] %s: %s -> %s\n", mv.Route.Path, mv.From, mv.To)
+			}
+			output.Printf("[DONE] Updated %d routes. Path, name, SEO and controller settings were kept.\n", len(selected))
+		}
+
+	case "fix_content":
+		if err := fixContentIssues(fixable); err != nil {
+			return err
+		}
+
+	case "remove_orphans":
+		if err := removeOrphans(orphans); err != nil {
+			return err
+		}
+
 	case "rescaffold":
-		fmt.Println("\nRe-scaffolding files...")
+		output.Println("\nRe-scaffolding files...")
 		for _, m := range missingRoutes {
+			output.Route(m.Route.Path)
 			var ctrlInfo *scaffold.ControllerInfo
 			if m.Route.Controller != "" {
 				ctrlInfo = &scaffold.ControllerInfo{Name: m.Route.Controller, Action: m.Route.Action}
 			}
-			scaffold.ScaffoldRouteFiles(
-				m.Route.Name,
-				m.Route.Files,
-				scaffold.ScaffoldOptions{NoView: false},
-				ctrlInfo,
-			)
+			if err := scaffold.ScaffoldRouteFiles(m.Route, scaffold.ScaffoldOptions{NoView: false}, ctrlInfo); err != nil {
+				return err
+			}
 		}
-		fmt.Println("[DONE] All files restored.")
+		output.Println("[DONE] All files restored.")
 
 	case "prune":
-		fmt.Println("\nPruning routes from JSON...")
+		output.Println("\nPruning routes from JSON...")
 		toRemove := make(map[string]bool)
 		for _, m := range missingRoutes {
 			toRemove[m.Route.Path] = true
+			output.Route(m.Route.Path)
 		}
 		var newRoutes []routes.Route
 		for _, rt := range r {
@@ -162,65 +218,20 @@ func runSync(cmd *cobra.Command, args []string) error {
 		if err := routes.Write(config.RoutesJSON, newRoutes); err != nil {
 			return err
 		}
-		fmt.Printf("[DONE] Removed %d routes from routes.json.\n", len(missingRoutes))
+		output.Modified(config.RoutesJSON, "[DONE] Removed %d routes from routes.json.\n", len(missingRoutes))
 
 	case "add_untracked":
-		fmt.Println("\nAnalyzing untracked files...")
+		output.Println("\nAnalyzing untracked files...")
 		var newRoutesToAdd []routes.Route
 		
 		for _, reactFile := range untrackedReact {
-			// Logic to infer route path
-			rel := strings.TrimPrefix(reactFile, "src/pages/")
-			var name string
-			if strings.HasSuffix(rel, "/index.page.tsx") {
-				name = strings.TrimSuffix(rel, "/index.page.tsx")
-			} else {
-				name = strings.TrimSuffix(rel, ".page.tsx")
-			}
-			
-			// PascalCase conversion logic?
-			// Assuming file structure usually matches desirable route name, just ensure capitalization
-			// For CLI v1, just taking it as is might be fine, or capitalising first letters.
-			// Node script does capitalisation.
-			parts := strings.Split(name, "/")
-			for i, p := range parts {
-				if len(p) > 0 {
-					parts[i] = strings.Title(strings.ToLower(p))
-				}
-			}
-			name = strings.Join(parts, "/")
-			pathStr := "/" + name
-
-			// View guess
-			viewCandidate := "Views/" + name + "/Index.cshtml"
-			viewCandidateFlat := "Views/" + name + ".cshtml"
-			
-			finalView := viewCandidate
-			// Check if we have untracked view match
-			for _, v := range untrackedViews {
-				if filepath.ToSlash(v) == viewCandidate {
-					finalView = viewCandidate
-					break
-				}
-				if filepath.ToSlash(v) == viewCandidateFlat {
-					finalView = viewCandidateFlat
-					break
-				}
-			}
-
-
-			newRoutesToAdd = append(newRoutesToAdd, routes.Route{
-				Path: pathStr,
-				Name: name,
-				Files: routes.Files{React: reactFile, View: finalView},
-				SEO: map[string]string{"title": name, "description": "Page for " + name},
-			})
+			newRoutesToAdd = append(newRoutesToAdd, inferUntrackedRoute(reactFile, untrackedViews))
 		}
 
 		if len(newRoutesToAdd) == 0 && len(untrackedViews) > 0 {
-			fmt.Println("[INFO] Found untracked Views but no corresponding React pages. Skipping automatic addition.")
+			output.Println("[INFO] Found untracked Views but no corresponding React pages. Skipping automatic addition.")
 		} else if len(newRoutesToAdd) > 0 {
-			fmt.Printf("Probe found %d potential new routes.\n", len(newRoutesToAdd))
+			output.Printf("Probe found %d potential new routes.\n", len(newRoutesToAdd))
 			
 			// Checkbox selection
 			checkboxChoices := []tui.Choice{}
@@ -241,14 +252,29 @@ func runSync(cmd *cobra.Command, args []string) error {
 				var idx int
 				fmt.Sscanf(idxStr, "%d", &idx)
 				routeToAdd := newRoutesToAdd[idx]
+				adopt, err := confirmAdoption(&routeToAdd, r)
+				if err != nil {
+					return err
+				}
+				if !adopt {
+					output.Printf("[SKIP] %s\n", routeToAdd.Files.React)
+					continue
+				}
 				r = append(r, routeToAdd)
+				output.Route(routeToAdd.Path)
 				
 				// Scaffold View if missing?
 				vPath := filepath.Join(config.ServerDir, routeToAdd.Files.View)
 				if _, err := os.Stat(vPath); os.IsNotExist(err) {
-					fmt.Printf("[Creating] Missing View for %s: %s\n", routeToAdd.Name, routeToAdd.Files.View)
 					os.MkdirAll(filepath.Dir(vPath), 0755)
-					os.WriteFile(vPath, []byte(scaffold.MVCView(routeToAdd.Name)), 0644)
+					view, err := scaffold.MVCView(scaffold.NewContext(routeToAdd))
+					if err != nil {
+						return err
+					}
+					if err := editorconfig.UpdateFile(vPath, view); err != nil {
+						return err
+					}
+					output.Created(vPath, "[Creating] Missing View for %s: %s\n", routeToAdd.Name, routeToAdd.Files.View)
 				}
 			}
 			
@@ -256,57 +282,197 @@ func runSync(cmd *cobra.Command, args []string) error {
 				if err := routes.Write(config.RoutesJSON, r); err != nil {
 					return err
 				}
+				output.Modified(config.RoutesJSON, "")
 			}
 		}
 
 	case "delete_untracked":
-		// Confirm deletion
-		filesToDelete := append(untrackedReact, untrackedViews...)
+		// Confirm deletion. Choices are relative to the project root; pages belong to the client
+		// project and views to the server project.
+		projects := make(map[string]string)
 		checkboxChoices := []tui.Choice{}
-		for _, f := range filesToDelete {
-			// Display relative to RootDir? Actually paths found are relative to Client/Server dir in my FindFiles usage?
-			// fsutil found them relative to ClientDir/ServerDir.
-			// Let's display with prefix.
-			display := "poyo.client/" + f
-			if strings.HasPrefix(f, "Views") {
-				display = "Poyo.Server/" + f
+		for _, f := range untrackedReact {
+			rel := fsutil.RelToRoot(filepath.Join(config.ClientDir, f))
+			projects[rel] = config.ClientDir
+			checkboxChoices = append(checkboxChoices, tui.Choice{Name: rel, Value: rel})
+		}
+		for _, f := range untrackedViews {
+			rel := fsutil.RelToRoot(filepath.Join(config.ServerDir, f))
+			projects[rel] = config.ServerDir
+			checkboxChoices = append(checkboxChoices, tui.Choice{Name: rel, Value: rel})
+		}
+
+		selectedFiles, err := tui.Checkbox("Select files to PERMANENTLY DELETE:", checkboxChoices)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range selectedFiles {
+			fullPath := filepath.Join(config.RootDir, filepath.FromSlash(f))
+			if err := os.Remove(fullPath); err == nil {
+				output.Deleted(fullPath, "[DELETED] %s\n", f)
+				fsutil.DeleteEmptyParents(fullPath, projects[f])
 			}
+		}
 
-			checkboxChoices = append(checkboxChoices, tui.Choice{
-				Name: display,
-				Value: f, // This value is relative to client/server dir
-			}) 
-			// Wait, we need to know which dir it belongs to to delete.
-			// "Views/..." implies ServerDir. "src/..." implies ClientDir.
+	case "ignore_untracked":
+		checkboxChoices := []tui.Choice{}
+		for _, f := range untrackedReact {
+			rel := fsutil.RelToRoot(filepath.Join(config.ClientDir, f))
+			checkboxChoices = append(checkboxChoices, tui.Choice{Name: rel, Value: rel})
+		}
+		for _, f := range untrackedViews {
+			rel := fsutil.RelToRoot(filepath.Join(config.ServerDir, f))
+			checkboxChoices = append(checkboxChoices, tui.Choice{Name: rel, Value: rel})
 		}
 
-		selectedFiles, err := tui.Checkbox("Select files to PERMANENTLY DELETE:", checkboxChoices)
+		selectedFiles, err := tui.Checkbox("Select files to add to .poyoignore:", checkboxChoices)
 		if err != nil {
 			return err
 		}
 
 		if len(selectedFiles) > 0 {
+			if err := fsutil.AppendIgnore(config.IgnoreFile, selectedFiles...); err != nil {
+				return err
+			}
 			for _, f := range selectedFiles {
-				var fullPath string
-				var root string
-				if strings.HasPrefix(f, "src") {
-					fullPath = filepath.Join(config.ClientDir, f)
-					root = config.ClientDir
-				} else {
-					fullPath = filepath.Join(config.ServerDir, f)
-					root = config.ServerDir
-				}
-				
-				if err := os.Remove(fullPath); err == nil {
-					fmt.Printf("[DELETED] %s\n", f)
-					fsutil.DeleteEmptyParents(fullPath, root)
-				}
+				output.Printf("[IGNORED] %s\n", f)
 			}
+			output.Modified(config.IgnoreFile, "[DONE] Added %d entries to .poyoignore.\n", len(selectedFiles))
 		}
 
 	default:
-		fmt.Println("[INFO] No changes made.")
+		output.Println("[INFO] No changes made.")
 	}
 
 	return nil
 }
+
+// MissingRoute is a route whose React page and/or MVC view is missing on disk.
+type MissingRoute struct {
+	Route        routes.Route
+	MissingFiles []string
+}
+
+// scanRoutes compares routes.json with the file system.
+// It returns routes with missing files, and page/view files that no route tracks.
+func scanRoutes(r []routes.Route) ([]MissingRoute, []string, []string) {
+	// 1. Forward Sync: Check missing files
+	var missingRoutes []MissingRoute
+
+	for _, rt := range r {
+		reactFullPath := filepath.Join(config.ClientDir, rt.Files.React)
+		viewFullPath := filepath.Join(config.ServerDir, rt.Files.View)
+		missing := []string{}
+
+		if _, err := os.Stat(reactFullPath); os.IsNotExist(err) {
+			missing = append(missing, "React Page")
+		}
+		if _, err := os.Stat(viewFullPath); os.IsNotExist(err) {
+			missing = append(missing, "MVC View")
+		}
+
+		if len(missing) > 0 {
+			missingRoutes = append(missingRoutes, MissingRoute{Route: rt, MissingFiles: missing})
+		}
+	}
+
+	// 2. Reverse Sync: Check untracked files
+	// React Pages
+	reactPages, _ := fsutil.FindFiles(
+		config.PagesDir,
+		func(path string) bool { return strings.HasSuffix(path, ".page.tsx") },
+		config.ClientDir,
+	)
+	// Views
+	viewPages, _ := fsutil.FindFiles(
+		config.ViewsDir,
+		func(path string) bool {
+			name := filepath.Base(path)
+			return strings.HasSuffix(path, ".cshtml") && !strings.Contains(path, "Shared") && !strings.HasPrefix(name, "_")
+		},
+		config.ServerDir,
+	)
+
+	// Comparison Sets
+	trackedReact := make(map[string]bool)
+	trackedView := make(map[string]bool)
+	for _, rt := range r {
+		trackedReact[filepath.ToSlash(rt.Files.React)] = true
+		trackedView[filepath.ToSlash(rt.Files.View)] = true
+	}
+
+	var untrackedReact []string
+	for _, f := range reactPages {
+		if !trackedReact[filepath.ToSlash(f)] {
+			untrackedReact = append(untrackedReact, f)
+		}
+	}
+	var untrackedViews []string
+	for _, f := range viewPages {
+		if !trackedView[filepath.ToSlash(f)] {
+			untrackedViews = append(untrackedViews, f)
+		}
+	}
+
+	return missingRoutes, untrackedReact, untrackedViews
+}
+
+// fixContentIssues lets the user pick which fixable content issues to rewrite.
+func fixContentIssues(issues []check.Issue) error {
+	checkboxChoices := []tui.Choice{}
+	for i, issue := range issues {
+		checkboxChoices = append(checkboxChoices, tui.Choice{
+			Name:  issue.String(),
+			Value: fmt.Sprintf("%d", i),
+		})
+	}
+
+	selectedIndicesStr, err := tui.Checkbox("Select issues to fix:", checkboxChoices)
+	if err != nil {
+		return err
+	}
+
+	for _, idxStr := range selectedIndicesStr {
+		var idx int
+		fmt.Sscanf(idxStr, "%d", &idx)
+		issue := issues[idx]
+		if err := check.Fix(issue); err != nil {
+			return err
+		}
+		output.Route(issue.Route.Path)
+		output.Modified(issue.File, "[FIXED] %s\n", fsutil.RelToRoot(issue.File))
+	}
+	return nil
+}
+
+// removeOrphans lets the user pick orphaned actions and removes just those methods.
+func removeOrphans(orphans []check.Orphan) error {
+	checkboxChoices := []tui.Choice{}
+	for i, o := range orphans {
+		checkboxChoices = append(checkboxChoices, tui.Choice{
+			Name:  o.String(),
+			Value: fmt.Sprintf("%d", i),
+		})
+	}
+
+	selectedIndicesStr, err := tui.Checkbox("Select controller actions to remove:", checkboxChoices)
+	if err != nil {
+		return err
+	}
+
+	for _, idxStr := range selectedIndicesStr {
+		var idx int
+		fmt.Sscanf(idxStr, "%d", &idx)
+		o := orphans[idx]
+		left, err := controllers.RemoveAction(o.File, o.Action)
+		if err != nil {
+			return err
+		}
+		output.Modified(o.File, "[DELETED] Action %s.%s\n", o.Controller, o.Action)
+		if left == 0 {
+			output.Printf("[INFO] %s has no actions left and can be deleted.\n", fsutil.RelToRoot(o.File))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+	"poyo-cli/internal/tui"
+)
+
+// inferUntrackedRoute builds a route for an untracked React page.
+// The layout is taken from the file name: "index.page.tsx" means folder layout, anything else is flat.
+func inferUntrackedRoute(reactFile string, untrackedViews []string) routes.Route {
+	rel := strings.TrimPrefix(reactFile, config.PagesPath()+"/")
+	isFlat := path.Base(rel) != "index.page.tsx"
+
+	var name string
+	if isFlat {
+		name = strings.TrimSuffix(rel, ".page.tsx")
+	} else {
+		name = strings.TrimSuffix(rel, "/index.page.tsx")
+	}
+
+	strategy := naming.Current()
+	segments := naming.Segments(name)
+	name = strategy.Name(segments)
+
+	// Prefer the view matching the page layout, then the other layout, matching case-insensitively.
+	preferred := routes.ResolvePaths(name, isFlat).View
+	alternate := routes.ResolvePaths(name, !isFlat).View
+	view := preferred
+	if v, ok := findFold(untrackedViews, preferred); ok {
+		view = v
+	} else if v, ok := findFold(untrackedViews, alternate); ok {
+		view = v
+	}
+
+	return routes.Route{
+		Path:  strategy.Path(segments),
+		Name:  name,
+		Files: routes.Files{React: reactFile, View: view},
+		SEO:   map[string]string{"title": name, "description": "Page for " + name},
+	}
+}
+
+func findFold(files []string, target string) (string, bool) {
+	for _, f := range files {
+		if strings.EqualFold(f, target) {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// errAdoptionCancelled is returned when the user cancels a prompt while adopting routes.
+var errAdoptionCancelled = errors.New("adoption cancelled")
+
+// confirmAdoption shows the inferred route and lets the user edit its path, name, view and access level.
+// It reports false if the user skips the route because its path is taken.
+func confirmAdoption(rt *routes.Route, existing []routes.Route) (bool, error) {
+	output.Printf("\n%s\n", rt.Files.React)
+	output.Printf("  Path:   %s\n", rt.Path)
+	output.Printf("  Name:   %s\n", rt.Name)
+	output.Printf("  View:   %s\n", rt.Files.View)
+	output.Printf("  Access: %s\n", routes.Access(*rt))
+
+	edit, err := tui.Confirm("Edit these settings?")
+	if err != nil {
+		return false, err
+	}
+
+	for {
+		if edit {
+			if err := editAdoption(rt); err != nil {
+				return false, err
+			}
+		}
+
+		conflict := false
+		for _, e := range existing {
+			if strings.EqualFold(e.Path, rt.Path) {
+				conflict = true
+				break
+			}
+		}
+		if !conflict {
+			return true, nil
+		}
+
+		output.Printf("[WARN] Route %s already exists in routes.json.\n", rt.Path)
+		choice, err := tui.Select("How should we proceed?", []tui.Choice{
+			{Name: "Edit: Choose another path", Value: "edit"},
+			{Name: "Skip: Do not adopt this page", Value: "skip"},
+			{Name: "Abort: Stop adopting routes", Value: "abort"},
+		})
+		if err != nil {
+			return false, err
+		}
+		switch choice {
+		case "edit":
+			edit = true
+		case "skip":
+			return false, nil
+		default:
+			return false, errAdoptionCancelled
+		}
+	}
+}
+
+func editAdoption(rt *routes.Route) error {
+	oldName := rt.Name
+
+	p, err := tui.Input("Path:", rt.Path)
+	if err != nil {
+		return err
+	}
+	rt.Path = "/" + strings.Trim(strings.TrimSpace(p), "/")
+
+	name, err := tui.Input("Name:", rt.Name)
+	if err != nil {
+		return err
+	}
+	rt.Name = strings.Trim(strings.TrimSpace(name), "/")
+
+	view, err := tui.Input("View:", rt.Files.View)
+	if err != nil {
+		return err
+	}
+	rt.Files.View = strings.TrimSpace(view)
+
+	access, err := tui.Select("Access level:", []tui.Choice{
+		{Name: "Protected (requires login)", Value: "protected"},
+		{Name: "Public", Value: "public"},
+		{Name: "Guest only", Value: "guest"},
+	})
+	if err != nil {
+		return err
+	}
+	if access == "" {
+		return errAdoptionCancelled
+	}
+	rt.IsPublic = access == "public"
+	rt.IsGuestOnly = access == "guest"
+
+	if rt.Name != oldName && rt.SEO != nil && rt.SEO["title"] == oldName {
+		rt.SEO["title"] = rt.Name
+		rt.SEO["description"] = "Page for " + rt.Name
+	}
+	return nil
+}
@@ -1,11 +1,12 @@
 package cmd
 
 import (
-	"fmt"
 	"strings"
 
 	"poyo-cli/internal/config"
+	"poyo-cli/internal/output"
 	"poyo-cli/internal/routes"
+	"poyo-cli/internal/scaffold"
 
 	"github.com/spf13/cobra"
 )
@@ -20,6 +21,8 @@ var updateCmd = &cobra.Command{
 	Short: "Update existing route properties",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runUpdate,
+
+	ValidArgsFunction: completeRoutePaths,
 }
 
 func init() {
@@ -45,7 +48,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if target == nil {
-		return fmt.Errorf("route not found: %s", urlPath)
+		return routeNotFound(r, urlPath)
 	}
 
 	updated := false
@@ -54,7 +57,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		val := strings.ToLower(updatePublic) == "true"
 		if target.IsPublic != val {
 			target.IsPublic = val
-			fmt.Printf("[UPDATE] Set isPublic to %v\n", val)
+			output.Printf("[UPDATE] Set isPublic to %v\n", val)
 			updated = true
 		}
 	}
@@ -63,7 +66,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		val := strings.ToLower(updateGuest) == "true"
 		if target.IsGuestOnly != val {
 			target.IsGuestOnly = val
-			fmt.Printf("[UPDATE] Set isGuestOnly to %v\n", val)
+			output.Printf("[UPDATE] Set isGuestOnly to %v\n", val)
 			updated = true
 		}
 	}
@@ -72,8 +75,21 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		if err := routes.Write(config.RoutesJSON, r); err != nil {
 			return err
 		}
+		output.Route(target.Path)
+		output.Modified(config.RoutesJSON, "")
+
+		// Keep the attributes of a custom controller action in line with the access level
+		if target.Controller != "" && target.Action != "" {
+			file, changed, err := scaffold.SyncAccess(*target)
+			if err != nil {
+				output.Printf("[WARN] Could not update the attributes of %s.%s: %v\n", target.Controller, target.Action, err)
+				output.Warn("attributes of %s.%s not updated: %v", target.Controller, target.Action, err)
+			} else if changed {
+				output.Modified(file, "[UPDATE] %s.%s is now %s\n", target.Controller, target.Action, routes.Access(*target))
+			}
+		}
 	} else {
-		fmt.Println("[INFO] No changes made.")
+		output.Println("[INFO] No changes made.")
 	}
 
 	return nil
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"poyo-cli/internal/check"
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+
+	"github.com/spf13/cobra"
+)
+
+var validateFix bool
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check routes.json against files and their contents (non-interactive)",
+	RunE:  runValidate,
+}
+
+func init() {
+	validateCmd.Flags().BoolVar(&validateFix, "fix", false, "Rewrite view attributes and controller view paths to match routes.json")
+
+	routeCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	r, err := routes.Read(config.RoutesJSON)
+	if err != nil {
+		return err
+	}
+
+	missingRoutes, untrackedReact, untrackedViews := scanRoutes(r)
+	contentIssues := check.Content(r)
+	orphans, err := check.Orphans(r)
+	if err != nil {
+		return err
+	}
+	problems := 0
+
+	for _, m := range missingRoutes {
+		for _, f := range m.MissingFiles {
+			output.Printf("[MISSING] %s: %s\n", m.Route.Path, f)
+			output.Warn("%s: missing %s", m.Route.Path, f)
+			problems++
+		}
+	}
+	for _, f := range untrackedReact {
+		output.Printf("[UNTRACKED] React Page: %s\n", f)
+		output.Warn("untracked React page: %s", f)
+		problems++
+	}
+	for _, f := range untrackedViews {
+		output.Printf("[UNTRACKED] MVC View: %s\n", f)
+		output.Warn("untracked MVC view: %s", f)
+		problems++
+	}
+
+	for _, issue := range contentIssues {
+		if validateFix && issue.Fixable {
+			if err := check.Fix(issue); err != nil {
+				return err
+			}
+			output.Route(issue.Route.Path)
+			output.Modified(issue.File, "[FIXED] %s (%s)\n", issue, fsutil.RelToRoot(issue.File))
+			continue
+		}
+		output.Printf("[INVALID] %s\n", issue)
+		output.Warn("%s", issue)
+		problems++
+	}
+
+	for _, o := range orphans {
+		output.Printf("[ORPHAN] %s\n", o)
+		output.Warn("orphaned action %s", o)
+		problems++
+	}
+
+	if problems > 0 {
+		cmd.SilenceUsage = true
+		return output.Errorf(output.ErrValidationFailed, "validation failed: %d problems found", problems)
+	}
+
+	output.Printf("[OK] All %d routes are consistent.\n", len(r))
+	return nil
+}
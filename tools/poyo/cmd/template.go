@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"sort"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Inspect scaffold templates",
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List built-in and project templates",
+	Long: `List the templates used to generate files, including the page variants for 'route add --template'.
+
+Files in ` + "`.poyo/templates/`" + ` replace the built-in template with the same name.
+A project file named page-<variant>.tsx.tmpl adds a page variant.`,
+	Args: cobra.NoArgs,
+	RunE: runTemplateList,
+}
+
+func init() {
+	templateCmd.AddCommand(templateListCmd)
+	RootCmd.AddCommand(templateCmd)
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	templates, err := scaffold.Templates()
+	if err != nil {
+		return err
+	}
+	output.Data("templates", templates)
+
+	var variants []scaffold.TemplateInfo
+	for _, t := range templates {
+		if t.Variant != "" {
+			variants = append(variants, t)
+		}
+	}
+	sort.Slice(variants, func(i, j int) bool {
+		return variants[i].Variant < variants[j].Variant
+	})
+
+	output.Println("Page variants (route add --template):")
+	for _, t := range variants {
+		output.Printf("  %-10s %s\n", t.Variant, templateSource(t))
+	}
+
+	output.Println("\nTemplates:")
+	for _, t := range templates {
+		output.Printf("  %-20s %s\n", t.Name, templateSource(t))
+	}
+
+	output.Printf("\nProject templates directory: %s\n", fsutil.RelToRoot(config.TemplatesDir))
+	return nil
+}
+
+func templateSource(t scaffold.TemplateInfo) string {
+	switch {
+	case t.Overrides:
+		return t.Path + " (overrides built-in)"
+	case t.Path != "":
+		return t.Path
+	}
+	return t.Source
+}
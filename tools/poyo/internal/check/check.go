@@ -0,0 +1,151 @@
+package check
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/routes"
+)
+
+type Kind string
+
+const (
+	PageNameMismatch       Kind = "page-name-mismatch"
+	PageNameMissing        Kind = "page-name-missing"
+	TitleMismatch          Kind = "title-mismatch"
+	ControllerMissing      Kind = "controller-missing"
+	ActionMissing          Kind = "action-missing"
+	ControllerViewMismatch Kind = "controller-view-mismatch"
+)
+
+// Issue is a content-level inconsistency between a route and its files.
+type Issue struct {
+	Route    routes.Route
+	Kind     Kind
+	File     string // Full path of the file holding the problem
+	Expected string
+	Actual   string
+	Fixable  bool
+}
+
+var (
+	pageNameRe = regexp.MustCompile(`data-page-name\s*=\s*"([^"]*)"`)
+	titleRe    = regexp.MustCompile(`ViewBag\.Title\s*=\s*"([^"]*)"\s*;`)
+)
+
+func (i Issue) String() string {
+	switch i.Kind {
+	case PageNameMismatch:
+		return fmt.Sprintf("%s: view data-page-name is %q, expected %q (the page will not render)", i.Route.Path, i.Actual, i.Expected)
+	case PageNameMissing:
+		return fmt.Sprintf("%s: view has no data-page-name attribute", i.Route.Path)
+	case TitleMismatch:
+		return fmt.Sprintf("%s: view sets ViewBag.Title to %q, overriding SEO title %q", i.Route.Path, i.Actual, i.Expected)
+	case ControllerMissing:
+		return fmt.Sprintf("%s: controller %s not found", i.Route.Path, controllers.FileName(i.Route.Controller))
+	case ActionMissing:
+		return fmt.Sprintf("%s: action %s not found in %s", i.Route.Path, i.Route.Action, controllers.FileName(i.Route.Controller))
+	case ControllerViewMismatch:
+		return fmt.Sprintf("%s: %s.%s returns View(\"~/%s\"), expected \"~/%s\"", i.Route.Path, i.Route.Controller, i.Route.Action, i.Actual, i.Expected)
+	}
+	return fmt.Sprintf("%s: %s", i.Route.Path, i.Kind)
+}
+
+// Content inspects the view and controller of every route whose files exist.
+func Content(r []routes.Route) []Issue {
+	var issues []Issue
+	for _, rt := range r {
+		issues = append(issues, viewIssues(rt)...)
+		issues = append(issues, controllerIssues(rt)...)
+	}
+	return issues
+}
+
+func viewIssues(rt routes.Route) []Issue {
+	viewPath := filepath.Join(config.ServerDir, rt.Files.View)
+	data, err := os.ReadFile(viewPath)
+	if err != nil {
+		return nil
+	}
+	content := string(data)
+	var issues []Issue
+
+	if m := pageNameRe.FindStringSubmatch(content); m == nil {
+		issues = append(issues, Issue{Route: rt, Kind: PageNameMissing, File: viewPath, Expected: rt.Name})
+	} else if m[1] != rt.Name {
+		issues = append(issues, Issue{Route: rt, Kind: PageNameMismatch, File: viewPath, Expected: rt.Name, Actual: m[1], Fixable: true})
+	}
+
+	// A title hard-coded in the view wins over the SEO title set by the controller.
+	if title := rt.SEO["title"]; title != "" {
+		if m := titleRe.FindStringSubmatch(content); m != nil && m[1] != title {
+			issues = append(issues, Issue{Route: rt, Kind: TitleMismatch, File: viewPath, Expected: title, Actual: m[1], Fixable: true})
+		}
+	}
+	return issues
+}
+
+func controllerIssues(rt routes.Route) []Issue {
+	if rt.Controller == "" || rt.Action == "" {
+		return nil
+	}
+	path := controllers.Path(config.ControllersDir, rt.Controller)
+	actions, _, err := controllers.ParseFile(path)
+	if err != nil {
+		return []Issue{{Route: rt, Kind: ControllerMissing, File: path}}
+	}
+
+	action, ok := controllers.Find(actions, rt.Action)
+	if !ok {
+		return []Issue{{Route: rt, Kind: ActionMissing, File: path}}
+	}
+	if action.View != "" && filepath.ToSlash(action.View) != filepath.ToSlash(rt.Files.View) {
+		return []Issue{{Route: rt, Kind: ControllerViewMismatch, File: path, Expected: rt.Files.View, Actual: action.View, Fixable: true}}
+	}
+	return nil
+}
+
+// Fix rewrites the file behind a fixable issue so it matches routes.json.
+func Fix(issue Issue) error {
+	if !issue.Fixable {
+		return fmt.Errorf("%s cannot be fixed automatically", issue.Kind)
+	}
+
+	data, err := os.ReadFile(issue.File)
+	if err != nil {
+		return err
+	}
+	content := string(data)
+	var out string
+
+	switch issue.Kind {
+	case PageNameMismatch:
+		out = replaceFirstGroup(pageNameRe, content, issue.Expected)
+	case TitleMismatch:
+		out = replaceFirstGroup(titleRe, content, issue.Expected)
+	case ControllerViewMismatch:
+		action, ok := controllers.Find(controllers.Parse(content), issue.Route.Action)
+		if !ok || action.View == "" {
+			return fmt.Errorf("action %s no longer returns an explicit view", issue.Route.Action)
+		}
+		out = content[:action.ViewStart] + issue.Expected + content[action.ViewEnd:]
+	default:
+		return fmt.Errorf("%s cannot be fixed automatically", issue.Kind)
+	}
+
+	return editorconfig.UpdateFile(issue.File, out)
+}
+
+func replaceFirstGroup(re *regexp.Regexp, content, value string) string {
+	m := re.FindStringSubmatchIndex(content)
+	if m == nil {
+		return content
+	}
+	return content[:m[2]] + strings.ReplaceAll(value, `"`, `\"`) + content[m[3]:]
+}
@@ -0,0 +1,74 @@
+package check
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/routes"
+)
+
+// Orphan is a controller action returning View("~/Views/...") that routes.json no longer uses.
+type Orphan struct {
+	File        string // Full path of the controller file
+	Controller  string
+	Action      string
+	View        string
+	ViewMissing bool
+	NoRoute     bool
+}
+
+func (o Orphan) String() string {
+	var reasons []string
+	if o.NoRoute {
+		reasons = append(reasons, "no route in routes.json")
+	}
+	if o.ViewMissing {
+		reasons = append(reasons, "view "+o.View+" does not exist")
+	}
+	return fmt.Sprintf("%s.%s: %s", o.Controller, o.Action, strings.Join(reasons, ", "))
+}
+
+// Orphans scans the controllers directory for actions serving views whose route or view is gone.
+func Orphans(r []routes.Route) ([]Orphan, error) {
+	files, err := controllers.Scan(config.ControllersDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []Orphan
+	for _, f := range files {
+		for _, a := range f.Actions {
+			if !strings.HasPrefix(a.View, config.ViewsPath()+"/") {
+				continue
+			}
+
+			o := Orphan{File: f.Path, Controller: f.Name, Action: a.Name, View: a.View}
+			if _, err := os.Stat(filepath.Join(config.ServerDir, a.View)); os.IsNotExist(err) {
+				o.ViewMissing = true
+			}
+			o.NoRoute = !routeUses(r, f.Name, a.Name)
+
+			if o.ViewMissing || o.NoRoute {
+				orphans = append(orphans, o)
+			}
+		}
+	}
+	return orphans, nil
+}
+
+func routeUses(r []routes.Route, controller, action string) bool {
+	for _, rt := range r {
+		if rt.Controller == "" {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSuffix(rt.Controller, "Controller"), strings.TrimSuffix(controller, "Controller")) &&
+			strings.EqualFold(rt.Action, action) {
+			return true
+		}
+	}
+	return false
+}
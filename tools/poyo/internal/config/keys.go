@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Keys returns the settings of poyo.config.json as dotted JSON names, e.g. "paths.client" or
+// "routeAdd.withTests", in file order. Apps are edited in the file itself.
+func Keys() []string {
+	var keys []string
+	walkKeys(reflect.TypeOf(Project{}), "", func(key string, _ []int) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+func walkKeys(t reflect.Type, prefix string, fn func(key string, index []int)) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" || f.Type.Kind() == reflect.Map {
+			continue
+		}
+		if f.Type.Kind() == reflect.Struct {
+			walkKeys(f.Type, prefix+name+".", func(key string, index []int) {
+				fn(key, append([]int{i}, index...))
+			})
+			continue
+		}
+		fn(prefix+name, []int{i})
+	}
+}
+
+// field returns the setting named key in p, matching case-insensitively.
+func (p *Project) field(key string) (reflect.Value, error) {
+	var index []int
+	walkKeys(reflect.TypeOf(*p), "", func(k string, i []int) {
+		if strings.EqualFold(k, key) {
+			index = i
+		}
+	})
+	if index == nil {
+		keys := Keys()
+		sort.Strings(keys)
+		return reflect.Value{}, fmt.Errorf("unknown setting %q (available: %s)", key, strings.Join(keys, ", "))
+	}
+	return reflect.ValueOf(p).Elem().FieldByIndex(index), nil
+}
+
+// Get returns the value of a setting, empty if it is not set.
+func (p *Project) Get(key string) (string, error) {
+	v, err := p.field(key)
+	if err != nil {
+		return "", err
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if !v.Bool() {
+			return "", nil
+		}
+		return "true", nil
+	}
+	return v.String(), nil
+}
+
+// Set changes a setting. An empty value removes it, restoring the default.
+func (p *Project) Set(key, value string) error {
+	v, err := p.field(key)
+	if err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		b := false
+		if value != "" {
+			if b, err = strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("%s expects true or false, got %q", key, value)
+			}
+		}
+		v.SetBool(b)
+	default:
+		v.SetString(value)
+	}
+	return nil
+}
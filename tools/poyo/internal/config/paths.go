@@ -1,61 +1,181 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// RootEnv names the environment variable selecting the project root, like the --root flag.
+const RootEnv = "POYO_ROOT"
+
+// The project layout, set by Load. At startup it holds a best-effort guess from POYO_ROOT or the
+// working directory, so that shell completion works without a full Load.
 var (
-	// Assumes running from poyo-cli directory or compiled binary in tools/poyo/bin
-	// If running with 'go run', we are in poyo-cli.
-	// We might need better logic later, but strictly following the brief for now.
-	// RootDir is now calculated dynamically
-	RootDir        = findProjectRoot()
-	ClientDir      = findDirWithSuffix(RootDir, ".client")
-	ServerDir      = findDirWithSuffix(RootDir, ".Server")
-	ControllersDir = filepath.Join(ServerDir, "Controllers")
-	RoutesJSON     = filepath.Join(RootDir, "routes.json")
+	RootDir        string
+	ClientDir      string
+	ServerDir      string
+	ControllersDir string
+	PagesDir       string
+	ViewsDir       string
+	RoutesJSON     string
+	IgnoreFile     string
+	TemplatesDir   string
 )
 
-func findProjectRoot() string {
-	dir, err := os.Getwd()
+func init() {
+	Load("", "")
+}
+
+// Load resolves the project layout. The root is root if set, else $POYO_ROOT, else the working
+// directory if it holds apps to discover, else the first folder from the working directory up
+// containing routes.json or poyo.config.json. In a workspace, the
+// project is then the app named app (else $POYO_APP), see selectApp. poyo.config.json paths are
+// applied over the folders found in the project.
+func Load(root, app string) error {
+	dir, err := findRoot(root)
 	if err != nil {
-		return "."
+		return err
+	}
+
+	WorkspaceDir, Apps, AppName = dir, nil, ""
+	if ws := enclosingWorkspace(dir); ws != "" {
+		WorkspaceDir = ws
+	}
+	setRoot(WorkspaceDir)
+	var declared bool
+	if Apps, declared, err = findApps(WorkspaceDir); err != nil {
+		return err
+	}
+
+	source := "--app"
+	if app == "" {
+		app, source = os.Getenv(AppEnv), AppEnv
+	}
+	if len(Apps) > 0 {
+		a, err := selectApp(app, source, dir, declared)
+		if err != nil {
+			return err
+		}
+		AppName = a.Name
+		setRoot(a.Dir)
+	} else if app != "" {
+		return fmt.Errorf("%s %s: %s is a single project, not a workspace with apps", source, app, dir)
+	}
+
+	p, err := LoadProject()
+	if err != nil {
+		return err
+	}
+	return Apply(p)
+}
+
+// setRoot points the files kept in the project root at dir. The other directories are left for
+// Apply, empty until then.
+func setRoot(dir string) {
+	ClientDir, ServerDir, ControllersDir, PagesDir, ViewsDir, RoutesJSON, TemplatesDir = "", "", "", "", "", "", ""
+	RootDir = dir
+	ProjectFile = filepath.Join(dir, "poyo.config.json")
+	IgnoreFile = filepath.Join(dir, ".poyoignore")
+}
+
+// isRoot reports whether dir holds a routes.json or poyo.config.json.
+func isRoot(dir string) bool {
+	for _, anchor := range []string{"routes.json", "poyo.config.json"} {
+		if _, err := os.Stat(filepath.Join(dir, anchor)); err == nil {
+			return true
+		}
 	}
+	return false
+}
 
-	for {
-		// Use routes.json as the stable anchor
-		if _, err := os.Stat(filepath.Join(dir, "routes.json")); err == nil {
-			return dir
+func findRoot(root string) (string, error) {
+	source := "--root"
+	if root == "" {
+		root, source = os.Getenv(RootEnv), RootEnv
+	}
+	if root != "" {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return "", err
+		}
+		if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+			return "", fmt.Errorf("%s %s is not a directory", source, root)
 		}
+		if !isRoot(abs) && len(discoverApps(abs)) == 0 {
+			return "", fmt.Errorf("%s %s is not a Poyo project: it has no routes.json or poyo.config.json, nor apps", source, root)
+		}
+		return abs, nil
+	}
 
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	// Apps are only discovered in the working directory: a parent holding unrelated projects is
+	// not a workspace
+	if len(discoverApps(cwd)) > 0 && !isRoot(cwd) {
+		return cwd, nil
+	}
+	for dir := cwd; ; {
+		if isRoot(dir) {
+			return dir, nil
+		}
 		parent := filepath.Dir(dir)
 		if parent == dir {
 			break
 		}
 		dir = parent
 	}
-	
-	d, _ := os.Getwd()
-	return d
+	return "", fmt.Errorf("no Poyo project found in %s or its parents (no routes.json or poyo.config.json); run poyo from the project or set --root or %s", cwd, RootEnv)
+}
+
+// DirError reports a client or server project that does not exist or cannot be inferred. Setting
+// names the poyo.config.json key that selects it.
+type DirError struct {
+	Setting string
+	msg     string
 }
 
-// findDirWithSuffix looks for a subdirectory ending with suffix (e.g. ".client")
-// If not found, it falls back to a default "poyo" + suffix for safety, though it likely won't exist.
-func findDirWithSuffix(root, suffix string) string {
+func (e *DirError) Error() string { return e.msg }
+
+// findProjectDir returns the folder of root whose name ends with suffix (e.g. ".Server"), ignoring
+// case. No match, or more than one, is a *DirError.
+func findProjectDir(root, suffix, setting string) (string, error) {
 	entries, err := os.ReadDir(root)
 	if err != nil {
-		return filepath.Join(root, "poyo"+suffix)
+		return "", err
 	}
+	var matches, near []string
 	for _, e := range entries {
-		if e.IsDir() && (len(e.Name()) >= len(suffix)) {
-            // Case insensitive check. Windows is usually case insensitive, Linux not.
-            // But the convention is likely consistent case.
-			if e.Name()[len(e.Name())-len(suffix):] == suffix {
-				return filepath.Join(root, e.Name())
-			}
+		if !e.IsDir() {
+			continue
+		}
+		name := strings.ToLower(e.Name())
+		switch {
+		case strings.HasSuffix(name, strings.ToLower(suffix)):
+			matches = append(matches, e.Name())
+		case strings.Contains(name, strings.ToLower(suffix)+"."):
+			// e.g. Poyo.Server.Tests: never the project itself, but worth a mention if nothing matches
+			near = append(near, e.Name())
 		}
 	}
-	// Fallback
-	return filepath.Join(root, "poyo"+suffix)
+	sort.Strings(matches)
+
+	var msg string
+	switch {
+	case len(matches) == 1:
+		return filepath.Join(root, matches[0]), nil
+	case len(matches) > 1:
+		msg = fmt.Sprintf("ambiguous %s project in %s: %s all end in %s",
+			strings.TrimPrefix(suffix, "."), root, strings.Join(matches, ", "), suffix)
+	case len(near) > 0:
+		msg = fmt.Sprintf("no folder ending in %s in %s (%s does not count)", suffix, root, strings.Join(near, ", "))
+	default:
+		msg = fmt.Sprintf("no folder ending in %s in %s", suffix, root)
+	}
+	msg += fmt.Sprintf("; set it with poyo config set %s <folder>", setting)
+	return "", &DirError{Setting: setting, msg: msg}
 }
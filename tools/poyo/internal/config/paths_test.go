@@ -0,0 +1,113 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// project creates folders (and files, for names with an extension) in a temporary root.
+func project(t *testing.T, names ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, name := range names {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		var err error
+		if filepath.Ext(name) == ".json" {
+			if err = os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+				err = os.WriteFile(path, []byte("[]"), 0644)
+			}
+		} else {
+			err = os.MkdirAll(path, 0755)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestApply(t *testing.T) {
+	savedRoot, savedFile := RootDir, ProjectFile
+	t.Cleanup(func() { setRoot(savedRoot); ProjectFile = savedFile })
+
+	tests := []struct {
+		name    string
+		folders []string
+		paths   PathsConfig
+		client  string // Expected, relative to the root
+		server  string
+		err     string // Expected error substring, with a *DirError
+	}{
+		{name: "inferred", folders: []string{"web.client", "Web.Server"}, client: "web.client", server: "Web.Server"},
+		{name: "case-insensitive", folders: []string{"web.Client", "Web.server"}, client: "web.Client", server: "Web.server"},
+		{name: "test project skipped", folders: []string{"web.client", "Web.Server", "Web.Server.Tests"}, client: "web.client", server: "Web.Server"},
+		{name: "only a test project", folders: []string{"web.client", "Web.Server.Tests"}, err: "no folder ending in .Server in " + "%root% (Web.Server.Tests does not count); set it with poyo config set paths.server"},
+		{name: "ambiguous", folders: []string{"web.client", "Web.Server", "Admin.Server"}, err: "ambiguous Server project in %root%: Admin.Server, Web.Server all end in .Server"},
+		{name: "missing", folders: []string{"Web.Server"}, err: "no folder ending in .client in %root%; set it with poyo config set paths.client"},
+		{name: "configured", folders: []string{"a.client", "b.client", "src/Web"}, paths: PathsConfig{Client: "b.client", Server: "src/Web"}, client: "b.client", server: "src/Web"},
+		{name: "configured missing", folders: []string{"web.client", "Web.Server"}, paths: PathsConfig{Client: "nope.client"}, err: "paths.client is nope.client, but %root%/nope.client does not exist"},
+		{name: "pages outside the client", folders: []string{"web.client", "Web.Server"}, paths: PathsConfig{Pages: "pages"}, err: "paths.pages must be inside the client project (web.client)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := project(t, tt.folders...)
+			setRoot(root)
+			err := Apply(Project{Paths: tt.paths})
+			if tt.err != "" {
+				want := strings.ReplaceAll(tt.err, "%root%", root)
+				if err == nil || !strings.Contains(err.Error(), filepath.FromSlash(want)) {
+					t.Fatalf("Apply() error = %v, want %q", err, want)
+				}
+				var dirErr *DirError
+				if !strings.Contains(tt.err, "paths.pages") && !errors.As(err, &dirErr) {
+					t.Errorf("Apply() error is a %T, want *DirError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rel(ClientDir); got != tt.client {
+				t.Errorf("ClientDir = %s, want %s", got, tt.client)
+			}
+			if got := rel(ServerDir); got != tt.server {
+				t.Errorf("ServerDir = %s, want %s", got, tt.server)
+			}
+			if want := filepath.Join(ClientDir, "src", "pages"); PagesDir != want {
+				t.Errorf("PagesDir = %s, want %s", PagesDir, want)
+			}
+		})
+	}
+}
+
+func TestFindRoot(t *testing.T) {
+	root := project(t, "routes.json", "web.client/src/pages")
+	t.Setenv(RootEnv, "")
+
+	t.Chdir(filepath.Join(root, "web.client", "src"))
+	if got, err := findRoot(""); err != nil || got != root {
+		t.Errorf("findRoot() from a subfolder = %q, %v, want %q", got, err, root)
+	}
+
+	t.Chdir(t.TempDir())
+	if _, err := findRoot(""); err == nil || !strings.Contains(err.Error(), "no Poyo project found") {
+		t.Errorf("findRoot() outside a project: %v", err)
+	}
+	if got, err := findRoot(root); err != nil || got != root {
+		t.Errorf("findRoot(--root) = %q, %v", got, err)
+	}
+	t.Setenv(RootEnv, root)
+	if got, err := findRoot(""); err != nil || got != root {
+		t.Errorf("findRoot($%s) = %q, %v", RootEnv, got, err)
+	}
+	t.Setenv(RootEnv, filepath.Join(root, "web.client"))
+	if _, err := findRoot(""); err == nil || !strings.Contains(err.Error(), RootEnv+" ") {
+		t.Errorf("findRoot($%s not a project) = %v", RootEnv, err)
+	}
+	if _, err := findRoot(filepath.Join(root, "missing")); err == nil || !strings.Contains(err.Error(), "--root") {
+		t.Errorf("findRoot(missing) = %v", err)
+	}
+}
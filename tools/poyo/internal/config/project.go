@@ -0,0 +1,153 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"poyo-cli/internal/editorconfig"
+)
+
+// ProjectFile is the optional per-project configuration file in the project root, set by Load.
+var ProjectFile string
+
+// Project is the content of poyo.config.json. Every field is optional.
+type Project struct {
+	Paths    PathsConfig    `json:"paths,omitzero"`
+	Naming   NamingConfig   `json:"naming,omitzero"`
+	RouteAdd RouteAddConfig `json:"routeAdd,omitzero"`
+
+	// Apps makes the project root a workspace: app name -> app folder, relative to the root. Each app
+	// is a project with its own routes.json and poyo.config.json.
+	Apps map[string]string `json:"apps,omitempty"`
+}
+
+// PathsConfig overrides the inferred project layout. Paths are relative to the project root;
+// empty ones are inferred.
+type PathsConfig struct {
+	Client      string `json:"client,omitempty"`      // Client project, default: the folder ending in ".client"
+	Server      string `json:"server,omitempty"`      // Server project, default: the folder ending in ".Server"
+	Controllers string `json:"controllers,omitempty"` // Default: <server>/Controllers
+	Pages       string `json:"pages,omitempty"`       // Inside the client project, default: <client>/src/pages
+	Views       string `json:"views,omitempty"`       // Inside the server project, default: <server>/Views
+	Routes      string `json:"routes,omitempty"`      // Default: routes.json
+	Templates   string `json:"templates,omitempty"`   // Project templates, default: .poyo/templates
+}
+
+// NamingConfig selects the naming strategies used when deriving routes and files.
+type NamingConfig struct {
+	URL  string `json:"url,omitempty"`  // "pascal" (default), "kebab" or "preserve"
+	File string `json:"file,omitempty"` // Flat page file names: "lower" (default), "kebab", "pascal" or "camel"
+}
+
+// RouteAddConfig holds defaults for route add flags.
+type RouteAddConfig struct {
+	Flat      bool   `json:"flat,omitempty"`      // Flat file structure as with --flat
+	NoView    bool   `json:"noView,omitempty"`    // Skip the MVC view as with --no-view
+	Layout    string `json:"layout,omitempty"`    // Razor layout as with --layout
+	Template  string `json:"template,omitempty"`  // Page variant as with --template
+	WithTests bool   `json:"withTests,omitempty"` // Generate tests as with --with-tests
+}
+
+// SaveProject writes poyo.config.json.
+func SaveProject(p Project) error {
+	data, err := json.MarshalIndent(p, "", "\t")
+	if err != nil {
+		return err
+	}
+	return editorconfig.RewriteFile(ProjectFile, string(data)+"\n")
+}
+
+// LoadProject reads poyo.config.json. A missing file yields an empty configuration.
+func LoadProject() (Project, error) {
+	return readProject(ProjectFile)
+}
+
+func readProject(file string) (Project, error) {
+	var p Project
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return p, nil
+		}
+		return p, err
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return p, fmt.Errorf("invalid %s: %w", file, err)
+	}
+	return p, nil
+}
+
+// Apply sets the project directories from p.Paths, inferring the ones it leaves empty. The client
+// and server projects must exist, and can only be inferred when exactly one folder of the root matches.
+func Apply(p Project) error {
+	dir := func(configured, inferred string) string {
+		if configured == "" {
+			return inferred
+		}
+		return filepath.Join(RootDir, filepath.FromSlash(configured))
+	}
+	project := func(configured, suffix, setting string) (string, error) {
+		if configured == "" {
+			return findProjectDir(RootDir, suffix, setting)
+		}
+		path := dir(configured, "")
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			return "", &DirError{Setting: setting, msg: fmt.Sprintf("%s: %s is %s, but %s does not exist; set it with poyo config set %s <folder>",
+				filepath.Base(ProjectFile), setting, configured, path, setting)}
+		}
+		return path, nil
+	}
+	client, err := project(p.Paths.Client, ".client", "paths.client")
+	if err != nil {
+		return err
+	}
+	server, err := project(p.Paths.Server, ".Server", "paths.server")
+	if err != nil {
+		return err
+	}
+	pages := dir(p.Paths.Pages, filepath.Join(client, "src", "pages"))
+	views := dir(p.Paths.Views, filepath.Join(server, "Views"))
+
+	// routes.json stores pages and views relative to their project
+	if !within(client, pages) {
+		return fmt.Errorf("%s: paths.pages must be inside the client project (%s)", filepath.Base(ProjectFile), rel(client))
+	}
+	if !within(server, views) {
+		return fmt.Errorf("%s: paths.views must be inside the server project (%s)", filepath.Base(ProjectFile), rel(server))
+	}
+
+	ClientDir, ServerDir, PagesDir, ViewsDir = client, server, pages, views
+	ControllersDir = dir(p.Paths.Controllers, filepath.Join(server, "Controllers"))
+	RoutesJSON = dir(p.Paths.Routes, filepath.Join(RootDir, "routes.json"))
+	TemplatesDir = dir(p.Paths.Templates, filepath.Join(RootDir, ".poyo", "templates"))
+	return nil
+}
+
+// PagesPath returns PagesDir relative to the client project, e.g. "src/pages".
+func PagesPath() string {
+	r, _ := filepath.Rel(ClientDir, PagesDir)
+	return filepath.ToSlash(r)
+}
+
+// ViewsPath returns ViewsDir relative to the server project, e.g. "Views".
+func ViewsPath() string {
+	r, _ := filepath.Rel(ServerDir, ViewsDir)
+	return filepath.ToSlash(r)
+}
+
+// within reports whether path is dir or below it.
+func within(dir, path string) bool {
+	r, err := filepath.Rel(dir, path)
+	return err == nil && r != ".." && !strings.HasPrefix(r, ".."+string(filepath.Separator))
+}
+
+// rel returns path relative to the project root, for messages.
+func rel(path string) string {
+	if r, err := filepath.Rel(RootDir, path); err == nil {
+		return filepath.ToSlash(r)
+	}
+	return path
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveProject(t *testing.T) {
+	saved := ProjectFile
+	t.Cleanup(func() { ProjectFile = saved })
+	ProjectFile = filepath.Join(t.TempDir(), "poyo.config.json")
+
+	tests := []struct {
+		name string
+		set  map[string]string
+		want string
+	}{
+		{"empty sections are left out", map[string]string{"naming.url": "kebab"}, "{\n\t\"naming\": {\n\t\t\"url\": \"kebab\"\n\t}\n}\n"},
+		{"nothing set", nil, "{}\n"},
+		{"bool", map[string]string{"routeAdd.withTests": "true", "paths.server": "src/Web.Server"}, "{\n\t\"paths\": {\n\t\t\"server\": \"src/Web.Server\"\n\t},\n\t\"routeAdd\": {\n\t\t\"withTests\": true\n\t}\n}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(ProjectFile)
+			var p Project
+			for k, v := range tt.set {
+				if err := p.Set(k, v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := SaveProject(p); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := os.ReadFile(ProjectFile); string(got) != tt.want {
+				t.Errorf("SaveProject() wrote %q, want %q", got, tt.want)
+			}
+			loaded, err := LoadProject()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tt.set {
+				if got, _ := loaded.Get(k); got != v {
+					t.Errorf("Get(%q) = %q, want %q", k, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectSet(t *testing.T) {
+	var p Project
+	if err := p.Set("ROUTEADD.FLAT", "true"); err != nil || !p.RouteAdd.Flat {
+		t.Errorf("Set is not case-insensitive: %v", err)
+	}
+	if err := p.Set("routeAdd.flat", ""); err != nil || p.RouteAdd.Flat {
+		t.Errorf("an empty value does not clear: %v", err)
+	}
+	if err := p.Set("routeAdd.flat", "maybe"); err == nil {
+		t.Error("Set accepted a non-boolean")
+	}
+	if err := p.Set("paths.nope", "x"); err == nil {
+		t.Error("Set accepted an unknown key")
+	}
+	for _, k := range Keys() {
+		if k == "apps" {
+			t.Error("Keys() lists apps")
+		}
+	}
+}
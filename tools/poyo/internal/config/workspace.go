@@ -0,0 +1,157 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AppEnv names the environment variable selecting the app of a workspace, like the --app flag.
+const AppEnv = "POYO_APP"
+
+// App is one project of a workspace: a folder with its own routes.json, client and server, and
+// optionally its own poyo.config.json.
+type App struct {
+	Name string `json:"name"`
+	Dir  string `json:"dir"`
+}
+
+// A workspace is a folder holding several apps, declared in the "apps" of its poyo.config.json or
+// discovered as the subfolders containing a routes.json. Load sets these alongside the layout of
+// the selected app.
+var (
+	WorkspaceDir string // The workspace folder, or the project root outside a workspace
+	Apps         []App  // Sorted by name, empty outside a workspace
+	AppName      string // The selected app, empty outside a workspace
+)
+
+// AppError reports that no app of the workspace was selected.
+type AppError struct {
+	msg string
+}
+
+func (e *AppError) Error() string { return e.msg }
+
+// FindApp returns the app of the workspace named name, ignoring case.
+func FindApp(name string) (App, bool) {
+	for _, a := range Apps {
+		if strings.EqualFold(a.Name, name) {
+			return a, true
+		}
+	}
+	return App{}, false
+}
+
+// AppNames returns the names of the apps, for messages and completion.
+func AppNames() []string {
+	names := make([]string, len(Apps))
+	for i, a := range Apps {
+		names[i] = a.Name
+	}
+	return names
+}
+
+// findApps returns the apps of the workspace dir, none if dir is a single project, and whether they
+// are declared in its poyo.config.json rather than discovered.
+func findApps(dir string) ([]App, bool, error) {
+	p, err := readProject(filepath.Join(dir, "poyo.config.json"))
+	if err != nil {
+		return nil, false, err
+	}
+
+	var apps []App
+	if len(p.Apps) > 0 {
+		for name, folder := range p.Apps {
+			app := App{Name: name, Dir: filepath.Join(dir, filepath.FromSlash(folder))}
+			if info, err := os.Stat(app.Dir); err != nil || !info.IsDir() {
+				return nil, false, fmt.Errorf("poyo.config.json: app %s: %s is not a directory", name, folder)
+			}
+			apps = append(apps, app)
+		}
+	} else if _, err := os.Stat(filepath.Join(dir, "routes.json")); err == nil {
+		return nil, false, nil
+	} else {
+		apps = discoverApps(dir)
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Name < apps[j].Name
+	})
+	return apps, len(p.Apps) > 0, nil
+}
+
+// discoverApps returns the subfolders of dir containing a routes.json, named after the folder.
+func discoverApps(dir string) []App {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var apps []App
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") || e.Name() == "node_modules" {
+			continue
+		}
+		sub := filepath.Join(dir, e.Name())
+		if _, err := os.Stat(filepath.Join(sub, "routes.json")); err == nil {
+			apps = append(apps, App{Name: e.Name(), Dir: sub})
+		}
+	}
+	return apps
+}
+
+// enclosingWorkspace returns the folder above dir whose poyo.config.json declares dir as an app,
+// so that commands run inside an app still see the others. The search stops at the first
+// poyo.config.json.
+func enclosingWorkspace(dir string) string {
+	for child, parent := dir, filepath.Dir(dir); parent != child; child, parent = parent, filepath.Dir(parent) {
+		file := filepath.Join(parent, "poyo.config.json")
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+		p, err := readProject(file)
+		if err != nil {
+			return ""
+		}
+		for _, folder := range p.Apps {
+			if within(filepath.Join(parent, filepath.FromSlash(folder)), dir) {
+				return parent
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// selectApp picks the app named name, else the app containing dir (the root found by Load) or the
+// working directory, else the only app of a declared workspace. A discovered app is never picked
+// from outside it: the folder may just happen to contain a project.
+func selectApp(name, source, dir string, declared bool) (App, error) {
+	if name != "" {
+		if a, ok := FindApp(name); ok {
+			return a, nil
+		}
+		return App{}, fmt.Errorf("%s %s: no such app in %s (apps: %s)", source, name, WorkspaceDir, strings.Join(AppNames(), ", "))
+	}
+
+	// The innermost app wins, in case one is declared as "." or nests another
+	cwd, _ := os.Getwd()
+	var found *App
+	for i, a := range Apps {
+		if (within(a.Dir, dir) || (cwd != "" && within(a.Dir, cwd))) && (found == nil || len(a.Dir) > len(found.Dir)) {
+			found = &Apps[i]
+		}
+	}
+	if found != nil {
+		return *found, nil
+	}
+	if len(Apps) == 1 && declared {
+		return Apps[0], nil
+	}
+	if len(Apps) == 1 {
+		return App{}, &AppError{fmt.Sprintf("%s is not a Poyo project, but its folder %s is; run poyo there, or choose it with --app %s",
+			WorkspaceDir, Apps[0].Name, Apps[0].Name)}
+	}
+	return App{}, &AppError{fmt.Sprintf("%s is a workspace with several apps (%s); choose one with --app or %s",
+		WorkspaceDir, strings.Join(AppNames(), ", "), AppEnv)}
+}
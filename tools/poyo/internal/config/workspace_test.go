@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadWorkspace(t *testing.T) {
+	t.Setenv(RootEnv, "")
+	t.Setenv(AppEnv, "")
+	t.Cleanup(func() { Load("", "") })
+
+	app := func(dir string) []string {
+		return []string{dir + "/routes.json", dir + "/web.client", dir + "/Web.Server"}
+	}
+	declared := project(t, append(append(app("apps/admin"), app("apps/customer")...), "tools")...)
+	config := `{"apps": {"admin": "apps/admin", "portal": "apps/customer"}}`
+	if err := os.WriteFile(filepath.Join(declared, "poyo.config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	discovered := project(t, append(append(app("shop"), app("blog")...), "unrelated/src")...)
+	single := project(t, append(app("shop"), "unrelated/src")...)
+
+	tests := []struct {
+		name     string
+		cwd      string
+		root     string
+		app      string
+		wantApp  string // Selected app, "" for a single project
+		wantRoot string // Expected RootDir
+		err      string // Expected error substring
+		appErr   bool   // The error is an *AppError
+	}{
+		{name: "declared, from the workspace", cwd: declared, app: "portal", wantApp: "portal", wantRoot: declared + "/apps/customer"},
+		{name: "declared, app name ignores case", cwd: declared, app: "ADMIN", wantApp: "admin", wantRoot: declared + "/apps/admin"},
+		{name: "declared, inside an app", cwd: declared + "/apps/admin/Web.Server", wantApp: "admin", wantRoot: declared + "/apps/admin"},
+		{name: "declared, another app from inside one", cwd: declared + "/apps/admin", app: "portal", wantApp: "portal", wantRoot: declared + "/apps/customer"},
+		{name: "declared, no app chosen", cwd: declared + "/tools", err: "workspace with several apps (admin, portal)", appErr: true},
+		{name: "declared, unknown app", cwd: declared, app: "nope", err: "--app nope: no such app"},
+		{name: "discovered", cwd: discovered, app: "blog", wantApp: "blog", wantRoot: discovered + "/blog"},
+		{name: "discovered, with --root", cwd: t.TempDir(), root: discovered, app: "shop", wantApp: "shop", wantRoot: discovered + "/shop"},
+		{name: "discovered, no app chosen", cwd: discovered, err: "workspace with several apps (blog, shop)", appErr: true},
+		{name: "discovered, inside an app", cwd: discovered + "/shop/web.client", wantRoot: discovered + "/shop"},
+		{name: "discovered, only one app", cwd: single, err: "its folder shop is; run poyo there", appErr: true},
+		{name: "no discovery from a subfolder", cwd: single + "/unrelated/src", err: "no Poyo project found"},
+		{name: "--app in a single project", cwd: single + "/shop", app: "shop", err: "is a single project"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(filepath.FromSlash(tt.cwd))
+			err := Load(tt.root, tt.app)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.err)
+				}
+				var appErr *AppError
+				if errors.As(err, &appErr) != tt.appErr {
+					t.Errorf("Load() error is a %T", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if AppName != tt.wantApp || RootDir != filepath.FromSlash(tt.wantRoot) {
+				t.Errorf("Load() selected %q in %s, want %q in %s", AppName, RootDir, tt.wantApp, tt.wantRoot)
+			}
+			if want := filepath.Join(RootDir, "routes.json"); RoutesJSON != want {
+				t.Errorf("RoutesJSON = %s, want %s", RoutesJSON, want)
+			}
+		})
+	}
+}
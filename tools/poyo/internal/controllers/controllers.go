@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"poyo-cli/internal/csharp"
+	"poyo-cli/internal/editorconfig"
+)
+
+// Action is a public method found in a controller source file.
+type Action struct {
+	Name string
+	// View is the path passed to View("~/..."), without the "~/" prefix. Empty if the action
+	// does not return an explicit application-relative view.
+	View string
+	// Byte offsets into the source: the whole method (including doc comments, attributes and
+	// leading indentation) and the view path literal contents.
+	Start, End         int
+	ViewStart, ViewEnd int
+}
+
+// ClassName returns the controller class name, adding the "Controller" suffix if missing.
+// A folder prefix is dropped: "Admin/Reports" gives "ReportsController".
+func ClassName(name string) string {
+	name = name[strings.LastIndex(name, "/")+1:]
+	if !strings.HasSuffix(name, "Controller") {
+		name += "Controller"
+	}
+	return name
+}
+
+// FileName returns the .cs file name for a controller, adding the "Controller" suffix if missing.
+func FileName(name string) string {
+	return ClassName(name) + ".cs"
+}
+
+// Path returns the full path of a controller file in dir. A name with a folder ("Admin/Reports")
+// is placed in that folder. Otherwise an existing file is looked up in the subfolders of dir,
+// and a new one goes to dir itself.
+func Path(dir, name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return filepath.Join(dir, filepath.FromSlash(name[:i]), FileName(name))
+	}
+	file := filepath.Join(dir, FileName(name))
+	if _, err := os.Stat(file); err == nil {
+		return file
+	}
+	found := ""
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && d.Name() == FileName(name) {
+			found = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if found != "" {
+		return found
+	}
+	return file
+}
+
+// ParseFile reads a controller and returns its actions.
+func ParseFile(path string) ([]Action, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	content := string(data)
+	return Parse(content), content, nil
+}
+
+// Parse returns the actions found in a controller source: the public methods of its controller
+// classes. Helper types declared in the same file are ignored.
+func Parse(content string) []Action {
+	f := csharp.Parse(content)
+	var actions []Action
+	for _, t := range f.Types {
+		if !IsController(t) {
+			continue
+		}
+		for _, m := range t.Members {
+			if m.Kind != csharp.Method || !m.HasModifier("public") || m.HasModifier("static") || m.HasAttribute("NonAction") {
+				continue
+			}
+			a := Action{Name: m.Name, Start: m.Start, End: m.End}
+			a.View, a.ViewStart, a.ViewEnd = viewPath(f, m)
+			actions = append(actions, a)
+		}
+	}
+	return actions
+}
+
+// IsController reports whether a type is an MVC controller: a non-abstract class named
+// "*Controller" or deriving from Controller/ControllerBase.
+func IsController(t *csharp.Type) bool {
+	if t.Kind != "class" {
+		return false
+	}
+	for _, mod := range t.Modifiers {
+		if mod == "abstract" || mod == "static" {
+			return false
+		}
+	}
+	if strings.HasSuffix(t.Name, "Controller") {
+		return true
+	}
+	for _, b := range t.Bases {
+		if strings.HasSuffix(b, "Controller") || strings.HasSuffix(b, "ControllerBase") {
+			return true
+		}
+	}
+	return false
+}
+
+// viewPath finds View("~/...") in a method and returns the path without "~/" and its offsets.
+func viewPath(f *csharp.File, m csharp.Member) (string, int, int) {
+	for i := m.First; i+2 <= m.Last; i++ {
+		if f.Tokens[i].Text != "View" || f.Tokens[i+1].Text != "(" || f.Tokens[i+2].Kind != csharp.String {
+			continue
+		}
+		value, offset := f.Tokens[i+2].Value()
+		if strings.HasPrefix(value, "~/") && !strings.HasPrefix(f.Tokens[i+2].Text, "$") {
+			return value[2:], offset + 2, offset + len(value)
+		}
+	}
+	return "", 0, 0
+}
+
+// Find returns the action with the given name (case-insensitive).
+func Find(actions []Action, name string) (Action, bool) {
+	for _, a := range actions {
+		if strings.EqualFold(a.Name, name) {
+			return a, true
+		}
+	}
+	return Action{}, false
+}
+
+// File is a parsed controller source file.
+type File struct {
+	Path    string
+	Name    string // Controller name as used in routes.json, e.g. "ReportsController"
+	Actions []Action
+}
+
+// Scan parses every controller under dir.
+func Scan(dir string) ([]File, error) {
+	var files []File
+	if _, err := os.Stat(dir); err != nil {
+		return files, nil
+	}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, "Controller.cs") {
+			return nil
+		}
+		actions, _, err := ParseFile(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{
+			Path:    path,
+			Name:    strings.TrimSuffix(filepath.Base(path), ".cs"),
+			Actions: actions,
+		})
+		return nil
+	})
+	return files, err
+}
+
+// Empty reports whether a controller source holds nothing but a controller class with at most the
+// generated ApplySeo helper, so that deleting the file loses no code of its own.
+func Empty(content string) bool {
+	f := csharp.Parse(content)
+	if len(f.Types) != 1 || !IsController(f.Types[0]) {
+		return false
+	}
+	for _, m := range f.Types[0].Members {
+		if m.Kind != csharp.Method || m.Name != "ApplySeo" {
+			return false
+		}
+	}
+	return true
+}
+
+// RemoveAction deletes an action method (and its attributes) from a controller file.
+// It returns the number of actions left in the file.
+func RemoveAction(path, name string) (int, error) {
+	actions, content, err := ParseFile(path)
+	if err != nil {
+		return 0, err
+	}
+	action, ok := Find(actions, name)
+	if !ok {
+		return len(actions), fmt.Errorf("action %s not found in %s", name, filepath.Base(path))
+	}
+
+	out := csharp.RemoveMember(content, csharp.Member{Start: action.Start, End: action.End})
+	if err := editorconfig.UpdateFile(path, out); err != nil {
+		return 0, err
+	}
+	return len(actions) - 1, nil
+}
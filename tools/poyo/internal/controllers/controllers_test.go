@@ -0,0 +1,33 @@
+package controllers
+
+import "testing"
+
+const applySeo = `
+    private void ApplySeo(string pageName)
+    {
+        ViewBag.Title = pageName;
+    }
+`
+
+func TestEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want bool
+	}{
+		{"no members", "namespace App;\n\npublic class OrdersController : Controller\n{\n}\n", true},
+		{"only ApplySeo", "using Microsoft.AspNetCore.Mvc;\n\nnamespace App;\n\npublic class OrdersController : Controller\n{" + applySeo + "}\n", true},
+		{"constructor", "public class OrdersController : Controller\n{\n    public OrdersController(IOrders orders) { }\n" + applySeo + "}\n", false},
+		{"injected field", "public class OrdersController : Controller\n{\n    private readonly IOrders _orders;\n}\n", false},
+		{"private helper", "public class OrdersController : Controller\n{\n    private int Count() => 0;\n}\n", false},
+		{"other type", "public class OrdersController : Controller\n{\n}\n\npublic record OrderRow(int Id);\n", false},
+		{"not a controller", "public class Orders\n{\n}\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Empty(tt.src); got != tt.want {
+				t.Errorf("Empty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package csharp
+
+import (
+	"strings"
+)
+
+// Type returns the first type declaration named name, or nil.
+func (f *File) Type(name string) *Type {
+	for _, t := range f.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Method returns the method of t named name (case-insensitive, like ASP.NET action names).
+func (t *Type) Method(name string) (Member, bool) {
+	for _, m := range t.Members {
+		if m.Kind == Method && strings.EqualFold(m.Name, name) {
+			return m, true
+		}
+	}
+	return Member{}, false
+}
+
+// HasModifier reports whether the member is declared with the modifier, e.g. "public".
+func (m Member) HasModifier(mod string) bool {
+	for _, v := range m.Modifiers {
+		if v == mod {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAttribute reports whether the member has the attribute, with or without the "Attribute"
+// suffix and namespace, e.g. "NonAction" matches [Microsoft.AspNetCore.Mvc.NonActionAttribute].
+func (m Member) HasAttribute(name string) bool {
+	for _, a := range m.Attributes {
+		short := a.Name[strings.LastIndex(a.Name, ".")+1:]
+		if short == name || short == name+"Attribute" {
+			return true
+		}
+	}
+	return false
+}
+
+// Indent returns the indentation of the line containing off.
+func Indent(src string, off int) string {
+	start := LineStart(src, off)
+	end := start
+	for end < len(src) && (src[end] == ' ' || src[end] == '\t') {
+		end++
+	}
+	return src[start:end]
+}
+
+// MemberIndent returns the indentation of t's members: that of its first member, or one level
+// deeper than the declaration when it has none.
+func (f *File) MemberIndent(t *Type) string {
+	if len(t.Members) > 0 {
+		return Indent(f.Source, f.Tokens[t.Members[0].First].Start)
+	}
+	return Indent(f.Source, t.Open) + f.IndentUnit()
+}
+
+// IndentUnit guesses one level of indentation from the first indented line: a tab or 4 spaces.
+func (f *File) IndentUnit() string {
+	for _, line := range strings.Split(f.Source, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			return "\t"
+		}
+		if strings.HasPrefix(line, " ") && strings.TrimSpace(line) != "" {
+			break
+		}
+	}
+	return "    "
+}
+
+// Reindent returns code, indented by levels of 4 spaces, with its least indented lines at indent
+// and each deeper level one unit more. Lines are ended by newline and blank lines emptied.
+func Reindent(code, indent, unit, newline string) string {
+	code = strings.Trim(strings.ReplaceAll(code, "\r\n", "\n"), "\n")
+	lines := strings.Split(code, "\n")
+	levels := make([]int, len(lines))
+	base := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		for strings.HasPrefix(line, "    ") {
+			line, levels[i] = line[4:], levels[i]+1
+		}
+		lines[i] = line
+		if base < 0 || levels[i] < base {
+			base = levels[i]
+		}
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = indent + strings.Repeat(unit, levels[i]-base) + line
+	}
+	return strings.Join(lines, newline) + newline
+}
+
+// InsertMember returns the source with code added as the last member of t. code is a member
+// indented by one level of 4 spaces; it is reindented to match t's members and separated from
+// them by a blank line.
+func (f *File) InsertMember(t *Type, code string) string {
+	if len(t.Members) == 0 {
+		return f.insert(t, t.Close, false, code)
+	}
+	return f.InsertMemberAfter(t, t.Members[len(t.Members)-1], code)
+}
+
+// InsertMemberAfter is like InsertMember, but adds code right after the member after.
+func (f *File) InsertMemberAfter(t *Type, after Member, code string) string {
+	return f.insert(t, after.End, true, code)
+}
+
+func (f *File) insert(t *Type, at int, afterMember bool, code string) string {
+	src := f.Source
+	indent := f.MemberIndent(t)
+	unit := f.IndentUnit()
+	if outer := Indent(src, t.Open); strings.HasPrefix(indent, outer) && len(indent) > len(outer) {
+		unit = indent[len(outer):]
+	}
+	newline := "\n"
+	if strings.Contains(src, "\r\n") {
+		newline = "\r\n"
+	}
+
+	code = Reindent(code, indent, unit, newline)
+
+	end := at
+	switch start := LineStart(src, at); {
+	case afterMember:
+		// After a member: separate it with a blank line
+		code = newline + code
+		if at > 0 && src[at-1] != '\n' {
+			code = newline + code // The member ends mid-line, e.g. "int x; // comment"
+		}
+	case strings.TrimSpace(src[start:at]) == "":
+		at, end = start, start
+	default:
+		// "{ }" on one line: break before the closing brace
+		at = len(strings.TrimRight(src[:at], " \t"))
+		code = newline + code + Indent(src, t.Open)
+	}
+	return src[:at] + code + src[end:]
+}
+
+// RemoveMember returns src without the member, along with the blank line that separated it from
+// its neighbours.
+func RemoveMember(src string, m Member) string {
+	start := m.Start
+	// Drop the blank line that separated the member from the previous one
+	before := strings.TrimRight(src[:start], " \t")
+	if strings.HasSuffix(before, "\n\n") || strings.HasSuffix(before, "\r\n\r\n") {
+		start = LineStart(src, len(before)-1)
+	}
+
+	end := m.End
+	if start == m.Start && strings.HasSuffix(strings.TrimRight(before, "\r\n \t"), "{") {
+		// First member of the type: drop the blank line that separated it from the next one
+		rest := src[end:]
+		if trimmed := strings.TrimLeft(rest, " \t"); strings.HasPrefix(trimmed, "\r\n") {
+			end += len(rest) - len(trimmed) + 2
+		} else if strings.HasPrefix(trimmed, "\n") {
+			end += len(rest) - len(trimmed) + 1
+		}
+	}
+	return src[:start] + src[end:]
+}
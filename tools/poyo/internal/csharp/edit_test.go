@@ -0,0 +1,119 @@
+package csharp
+
+import (
+	"strings"
+	"testing"
+)
+
+const action = `    [HttpGet]
+    public IActionResult Added()
+    {
+        return View();
+    }`
+
+func TestInsertMember(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "after the last member",
+			src:  "public class A : Controller\n{\n    public IActionResult Index() => View();\n}\n",
+			want: "public class A : Controller\n{\n    public IActionResult Index() => View();\n\n    [HttpGet]\n    public IActionResult Added()\n    {\n        return View();\n    }\n}\n",
+		},
+		{
+			name: "empty body",
+			src:  "public class A\n{\n}\n",
+			want: "public class A\n{\n    [HttpGet]\n    public IActionResult Added()\n    {\n        return View();\n    }\n}\n",
+		},
+		{
+			name: "{ } on one line",
+			src:  "public class A { }\n",
+			want: "public class A {\n    [HttpGet]\n    public IActionResult Added()\n    {\n        return View();\n    }\n}\n",
+		},
+		{
+			name: "tabs and CRLF",
+			src:  "namespace App\r\n{\r\n\tpublic class A\r\n\t{\r\n\t\tpublic void B() { }\r\n\t}\r\n}\r\n",
+			want: "namespace App\r\n{\r\n\tpublic class A\r\n\t{\r\n\t\tpublic void B() { }\r\n\r\n\t\t[HttpGet]\r\n\t\tpublic IActionResult Added()\r\n\t\t{\r\n\t\t\treturn View();\r\n\t\t}\r\n\t}\r\n}\r\n",
+		},
+		{
+			name: "before a trailing helper class",
+			src:  "namespace App;\n\npublic class A\n{\n    public void B() { }\n}\n\ninternal class Helper\n{\n    public void C() { }\n}\n",
+			want: "namespace App;\n\npublic class A\n{\n    public void B() { }\n\n    [HttpGet]\n    public IActionResult Added()\n    {\n        return View();\n    }\n}\n\ninternal class Helper\n{\n    public void C() { }\n}\n",
+		},
+		{
+			name: "member ending with a comment",
+			src:  "class A\n{\n    int x; // count\n}\n",
+			want: "class A\n{\n    int x; // count\n\n    [HttpGet]\n    public IActionResult Added()\n    {\n        return View();\n    }\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := Parse(tt.src)
+			if got := f.InsertMember(f.Types[0], action); got != tt.want {
+				t.Errorf("InsertMember() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertMemberAfter(t *testing.T) {
+	src := "class A\n{\n    public void First() { }\n\n    public void Last() { }\n}\n"
+	want := "class A\n{\n    public void First() { }\n\n    [HttpGet]\n    public IActionResult Added()\n    {\n        return View();\n    }\n\n    public void Last() { }\n}\n"
+	f := Parse(src)
+	first, _ := f.Types[0].Method("First")
+	if got := f.InsertMemberAfter(f.Types[0], first, action); got != want {
+		t.Errorf("InsertMemberAfter() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	const src = `public class A : Controller
+{
+    public IActionResult First() => View();
+
+    /// <summary>Middle</summary>
+    [HttpGet]
+    public async Task<IActionResult> Middle()
+    {
+        return View("}");
+    }
+
+    public IActionResult Last() { }
+}
+`
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{"First", "public class A : Controller\n{\n    /// <summary>Middle</summary>\n    [HttpGet]\n    public async Task<IActionResult> Middle()\n    {\n        return View(\"}\");\n    }\n\n    public IActionResult Last() { }\n}\n"},
+		{"Middle", "public class A : Controller\n{\n    public IActionResult First() => View();\n\n    public IActionResult Last() { }\n}\n"},
+		{"Last", "public class A : Controller\n{\n    public IActionResult First() => View();\n\n    /// <summary>Middle</summary>\n    [HttpGet]\n    public async Task<IActionResult> Middle()\n    {\n        return View(\"}\");\n    }\n}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			f := Parse(src)
+			m, ok := f.Types[0].Method(tt.method)
+			if !ok {
+				t.Fatalf("%s not found", tt.method)
+			}
+			if got := RemoveMember(src, m); got != tt.want {
+				t.Errorf("RemoveMember() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	// Removing every member, one at a time, leaves the empty type
+	rest := src
+	for _, name := range []string{"Middle", "First", "Last"} {
+		m, _ := Parse(rest).Types[0].Method(name)
+		rest = RemoveMember(rest, m)
+	}
+	if want := "public class A : Controller\n{\n}\n"; rest != want {
+		t.Errorf("after removing every member: %q, want %q", rest, want)
+	}
+	if strings.Contains(rest, "\n\n") {
+		t.Errorf("blank lines left: %q", rest)
+	}
+}
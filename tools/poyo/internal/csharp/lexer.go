@@ -0,0 +1,251 @@
+// Package csharp is a lightweight structural parser for C# sources. It understands enough of the
+// language (comments, all string literal forms, preprocessor lines, namespaces, type declarations,
+// attributes and member signatures) to find and edit members without being confused by braces in
+// strings or comments. Method bodies are not parsed.
+package csharp
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Kind int
+
+const (
+	Ident  Kind = iota // Identifiers and keywords
+	String             // "..", @"..", $"..", """..""", u8 suffixes included
+	Char               // '.'
+	Number
+	Punct // Single characters, plus "=>" and "::"
+)
+
+// Token is a lexical token. Comments, whitespace and preprocessor lines are skipped.
+type Token struct {
+	Kind       Kind
+	Text       string
+	Start, End int // Byte offsets into the source
+}
+
+// Tokenize splits src into tokens.
+func Tokenize(src string) []Token {
+	var tokens []Token
+	lineStart := true // Only whitespace seen since the last newline (for preprocessor lines)
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			lineStart = true
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			i++
+			continue
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			i = skipLine(src, i)
+			continue
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			if end := strings.Index(src[i+2:], "*/"); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(src)
+			}
+			continue
+		case c == '#' && lineStart:
+			i = skipLine(src, i)
+			continue
+		}
+		lineStart = false
+
+		start := i
+		kind := Punct
+		switch {
+		case isStringStart(src, i):
+			kind, i = String, scanString(src, i)
+		case c == '\'':
+			kind, i = Char, scanChar(src, i)
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			kind, i = Number, scanNumber(src, i)
+		case isIdentStart(src, i):
+			kind, i = Ident, scanIdent(src, i)
+		case c == '=' && i+1 < len(src) && src[i+1] == '>', c == ':' && i+1 < len(src) && src[i+1] == ':':
+			i += 2
+		default:
+			_, size := utf8.DecodeRuneInString(src[i:])
+			i += size
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: src[start:i], Start: start, End: i})
+	}
+	return tokens
+}
+
+func skipLine(src string, i int) int {
+	if end := strings.IndexByte(src[i:], '\n'); end >= 0 {
+		return i + end
+	}
+	return len(src)
+}
+
+// isStringStart reports whether a string literal starts at i: ", @", $", $@", @$", $$""" etc.
+func isStringStart(src string, i int) bool {
+	for ; i < len(src); i++ {
+		switch src[i] {
+		case '"':
+			return true
+		case '$', '@':
+			continue
+		}
+		return false
+	}
+	return false
+}
+
+// scanString returns the offset just after the string literal starting at i.
+func scanString(src string, i int) int {
+	verbatim, dollars := false, 0
+	for src[i] != '"' {
+		if src[i] == '@' {
+			verbatim = true
+		} else {
+			dollars++
+		}
+		i++
+	}
+
+	// Raw string literal: three or more quotes
+	quotes := 0
+	for i+quotes < len(src) && src[i+quotes] == '"' {
+		quotes++
+	}
+	if quotes >= 3 {
+		closing := strings.Repeat(`"`, quotes)
+		if end := strings.Index(src[i+quotes:], closing); end >= 0 {
+			return suffix(src, i+quotes+end+quotes)
+		}
+		return len(src)
+	}
+
+	i++ // Opening quote
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '\\' && !verbatim:
+			i += 2
+		case c == '"' && verbatim && i+1 < len(src) && src[i+1] == '"':
+			i += 2
+		case c == '"':
+			return suffix(src, i+1)
+		case c == '{' && dollars > 0:
+			if i+1 < len(src) && src[i+1] == '{' {
+				i += 2
+				continue
+			}
+			i = scanInterpolation(src, i)
+		case c == '\n' && !verbatim:
+			return i // Unterminated
+		default:
+			i++
+		}
+	}
+	return len(src)
+}
+
+// scanInterpolation skips an interpolation hole "{...}" that may contain nested strings.
+func scanInterpolation(src string, i int) int {
+	depth := 0
+	for i < len(src) {
+		switch c := src[i]; {
+		case c == '{':
+			depth++
+			i++
+		case c == '}':
+			depth--
+			i++
+			if depth == 0 {
+				return i
+			}
+		case isStringStart(src, i):
+			i = scanString(src, i)
+		case c == '\'':
+			i = scanChar(src, i)
+		default:
+			i++
+		}
+	}
+	return len(src)
+}
+
+// suffix skips the u8 suffix of UTF-8 string literals.
+func suffix(src string, i int) int {
+	if strings.HasPrefix(src[i:], "u8") || strings.HasPrefix(src[i:], "U8") {
+		return i + 2
+	}
+	return i
+}
+
+func scanChar(src string, i int) int {
+	for i++; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '\'':
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+	return len(src)
+}
+
+func scanNumber(src string, i int) int {
+	for i < len(src) {
+		c := src[i]
+		if c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '.' {
+			// Stop at member access on a literal, e.g. "1.ToString()"
+			if c == '.' && (i+1 >= len(src) || src[i+1] < '0' || src[i+1] > '9') {
+				break
+			}
+			i++
+			continue
+		}
+		break
+	}
+	return i
+}
+
+func isIdentStart(src string, i int) bool {
+	if src[i] == '@' && i+1 < len(src) {
+		i++ // Verbatim identifier, e.g. @class
+	}
+	r, _ := utf8.DecodeRuneInString(src[i:])
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func scanIdent(src string, i int) int {
+	if src[i] == '@' {
+		i++
+	}
+	for i < len(src) {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// Value returns the contents of a regular or verbatim string token without quotes and prefixes,
+// and the offset of the contents in the source. Escape sequences are not decoded.
+func (t Token) Value() (string, int) {
+	if t.Kind != String {
+		return "", t.Start
+	}
+	open := strings.IndexByte(t.Text, '"')
+	text := strings.TrimSuffix(strings.TrimSuffix(t.Text, "u8"), "U8")
+	if open < 0 || len(text) < open+2 {
+		return "", t.Start
+	}
+	return text[open+1 : len(text)-1], t.Start + open + 1
+}
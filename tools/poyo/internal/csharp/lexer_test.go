@@ -0,0 +1,54 @@
+package csharp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string // Token texts
+	}{
+		{"a => b::c", []string{"a", "=>", "b", "::", "c"}},
+		{"x // } comment\ny", []string{"x", "y"}},
+		{"x /* { */ y", []string{"x", "y"}},
+		{"#region Actions\nx\n#endregion", []string{"x"}},
+		{`f("}", '}')`, []string{"f", "(", `"}"`, ",", `'}'`, ")"}},
+		{`a = "\"}";`, []string{"a", "=", `"\"}"`, ";"}},
+		{`a = @"C:\""}";`, []string{"a", "=", `@"C:\""}"`, ";"}},
+		{`a = $"{b} {{ }}";`, []string{"a", "=", `$"{b} {{ }}"`, ";"}},
+		{`a = $"{f("}")}";`, []string{"a", "=", `$"{f("}")}"`, ";"}},
+		{"a = \"\"\"\n  \"}\"\n  \"\"\";", []string{"a", "=", "\"\"\"\n  \"}\"\n  \"\"\"", ";"}},
+		{`a = "x"u8;`, []string{"a", "=", `"x"u8`, ";"}},
+		{"n = 1_000.5m;", []string{"n", "=", "1_000.5m", ";"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, tok := range Tokenize(tt.src) {
+			got = append(got, tok.Text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestTokenValue(t *testing.T) {
+	tests := []struct {
+		src   string
+		want  string
+		start int
+	}{
+		{`"~/Views/Home.cshtml"`, "~/Views/Home.cshtml", 1},
+		{`@"a\b"`, `a\b`, 2},
+		{`"x"u8`, "x", 1},
+		{`""`, "", 1},
+	}
+	for _, tt := range tests {
+		tok := Tokenize(tt.src)[0]
+		if got, start := tok.Value(); got != tt.want || start != tt.start {
+			t.Errorf("Value(%s) = %q, %d, want %q, %d", tt.src, got, start, tt.want, tt.start)
+		}
+	}
+}
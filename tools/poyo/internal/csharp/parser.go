@@ -0,0 +1,497 @@
+package csharp
+
+import (
+	"strings"
+)
+
+// File is the structure of a C# source file.
+type File struct {
+	Source string
+	Tokens []Token
+	Usings []Using
+	Types  []*Type // Every type declaration in source order, nested types included
+}
+
+// Using is a using directive, e.g. "using System.Text.Json;".
+type Using struct {
+	Name       string // Namespace or alias target
+	Start, End int    // The directive, ";" included
+}
+
+// Type is a class, record, struct or interface declaration.
+type Type struct {
+	Kind       string // "class", "record", "struct" or "interface"
+	Name       string
+	Namespace  string // Enclosing namespace, empty for the global namespace
+	Modifiers  []string
+	Attributes []Attribute
+	Bases      []string // Base type and interfaces, e.g. ["Controller"]
+	Parent     *Type    // Enclosing type of a nested type
+	Members    []Member
+	// Byte offsets: the declaration (from the start of its first line) and the body braces.
+	// Open and Close are -1 for declarations without a body, e.g. "record Point(int X, int Y);".
+	Start, End  int
+	Open, Close int
+}
+
+// Member is a method, constructor, property, field or other member of a type.
+type Member struct {
+	Kind       MemberKind
+	Name       string
+	ReturnType string // e.g. "Task<IActionResult>" (modifiers such as async are in Modifiers)
+	Modifiers  []string
+	Attributes []Attribute
+	Params     string // Parameter list without parentheses
+	// Byte offsets: the member (from the start of its first line, doc comments and attributes
+	// included, to the end of its last line) and the body braces (-1 for "=>" and ";" members).
+	Start, End  int
+	Open, Close int
+	// Index range of the member's tokens in File.Tokens
+	First, Last int
+	Decl        int // Offset of the declaration after the attributes (its first modifier)
+}
+
+type MemberKind int
+
+const (
+	Field MemberKind = iota
+	Property
+	Method
+	Constructor
+	Other // Events, indexers, operators, enums, delegates
+)
+
+// Attribute is one attribute of an attribute list, e.g. Authorize in "[Authorize, HttpGet]".
+type Attribute struct {
+	Name               string // e.g. "Authorize" or "Poyo.Server.Middleware.Auth.GuestOnly"
+	Args               string // Arguments without parentheses
+	Start, End         int    // The attribute, arguments included
+	ListStart, ListEnd int    // The attribute list brackets (shared by attributes of the same list)
+}
+
+var modifiers = map[string]bool{
+	"public": true, "private": true, "protected": true, "internal": true, "file": true,
+	"static": true, "abstract": true, "sealed": true, "virtual": true, "override": true,
+	"async": true, "partial": true, "readonly": true, "new": true, "extern": true,
+	"unsafe": true, "required": true, "volatile": true, "const": true,
+}
+
+// Parse parses the structure of a C# source.
+func Parse(src string) *File {
+	f := &File{Source: src, Tokens: Tokenize(src)}
+	p := parser{File: f, match: matchBrackets(f.Tokens)}
+	p.block(0, len(f.Tokens), "", nil)
+	return f
+}
+
+type parser struct {
+	*File
+	match []int // Index of the matching bracket token, -1 if unmatched or not a bracket
+}
+
+// matchBrackets pairs (), [] and {} tokens.
+func matchBrackets(tokens []Token) []int {
+	match := make([]int, len(tokens))
+	var stack []int
+	for i, t := range tokens {
+		match[i] = -1
+		if t.Kind != Punct {
+			continue
+		}
+		switch t.Text {
+		case "(", "[", "{":
+			stack = append(stack, i)
+		case ")", "]", "}":
+			open := map[string]string{")": "(", "]": "[", "}": "{"}[t.Text]
+			// Pop until the matching opener, tolerating unbalanced sources
+			for j := len(stack) - 1; j >= 0; j-- {
+				if tokens[stack[j]].Text == open {
+					match[i], match[stack[j]] = stack[j], i
+					stack = stack[:j]
+					break
+				}
+			}
+		}
+	}
+	return match
+}
+
+func (p *parser) text(i int) string {
+	if i < 0 || i >= len(p.Tokens) {
+		return ""
+	}
+	return p.Tokens[i].Text
+}
+
+// skip returns the index after the bracket matching token i, or limit if it has none.
+func (p *parser) skip(i, limit int) int {
+	if m := p.match[i]; m > i && m < limit {
+		return m + 1
+	}
+	return limit
+}
+
+// block parses namespace or type members in tokens [i, limit).
+func (p *parser) block(i, limit int, ns string, parent *Type) {
+	for i < limit {
+		first := i
+		var attrs []Attribute
+		for p.text(i) == "[" {
+			next := p.skip(i, limit)
+			attrs = append(attrs, p.attributes(i, next-1)...)
+			i = next
+		}
+		var mods []string
+		for i < limit && p.Tokens[i].Kind == Ident && modifiers[p.text(i)] {
+			mods = append(mods, p.text(i))
+			i++
+		}
+		if i >= limit {
+			return
+		}
+
+		switch word := p.text(i); {
+		case word == ";" || word == "}":
+			i++
+		case (word == "global" && p.text(i+1) == "using") || (word == "using" && parent == nil):
+			end := p.until(i, limit, ";")
+			if word == "using" || p.text(i+1) == "using" {
+				p.using(i, end)
+			}
+			i = end + 1
+		case word == "namespace":
+			j := i + 1
+			var name strings.Builder
+			for j < limit && p.text(j) != ";" && p.text(j) != "{" {
+				name.WriteString(p.text(j))
+				j++
+			}
+			full := joinNamespace(ns, name.String())
+			if p.text(j) == ";" {
+				p.block(j+1, limit, full, nil) // File-scoped: the rest of the file
+				return
+			}
+			close := p.skip(j, limit) - 1
+			p.block(j+1, close, full, nil)
+			i = close + 1
+		case word == "class" || word == "struct" || word == "interface" || word == "record":
+			i = p.typeDecl(first, i, limit, ns, parent, attrs, mods)
+		case word == "enum" || word == "delegate" || word == "event":
+			end := p.memberEnd(i, limit)
+			p.addMember(parent, Member{Kind: Other, Name: p.text(i + 1), Modifiers: mods, Attributes: attrs, Open: -1, Close: -1}, first, end)
+			i = end
+		default:
+			i = p.member(first, i, limit, parent, attrs, mods)
+		}
+	}
+}
+
+// until returns the index of the first tok at bracket depth 0 from i, or limit.
+func (p *parser) until(i, limit int, tok string) int {
+	for i < limit {
+		if p.text(i) == tok {
+			return i
+		}
+		if p.match[i] > i {
+			i = p.match[i] + 1
+			continue
+		}
+		i++
+	}
+	return limit
+}
+
+// memberEnd returns the index after a member that ends with ";" or a braced body.
+func (p *parser) memberEnd(i, limit int) int {
+	for i < limit {
+		switch p.text(i) {
+		case ";":
+			return i + 1
+		case "{":
+			i = p.skip(i, limit)
+			if p.text(i) == ";" && i < limit {
+				i++
+			}
+			return i
+		}
+		if p.match[i] > i {
+			i = p.match[i]
+		}
+		i++
+	}
+	return limit
+}
+
+func (p *parser) using(i, end int) {
+	var name strings.Builder
+	for j := i + 1; j < end; j++ {
+		switch t := p.text(j); t {
+		case "using", "static":
+			continue
+		case "=":
+			name.Reset() // Alias: keep the target
+		default:
+			name.WriteString(t)
+		}
+	}
+	p.Usings = append(p.Usings, Using{Name: name.String(), Start: p.Tokens[i].Start, End: p.Tokens[min(end, len(p.Tokens)-1)].End})
+}
+
+func joinNamespace(outer, inner string) string {
+	if outer == "" {
+		return inner
+	}
+	return outer + "." + inner
+}
+
+// attributes parses the attribute list between the brackets at open and close.
+func (p *parser) attributes(open, close int) []Attribute {
+	var attrs []Attribute
+	start, end := p.Tokens[open].Start, p.Tokens[min(close, len(p.Tokens)-1)].End
+	i := open + 1
+	// Attribute target, e.g. "[assembly: ...]" or "[return: ...]"
+	if p.text(i+1) == ":" {
+		i += 2
+	}
+	for i < close {
+		a := Attribute{Start: p.Tokens[i].Start, ListStart: start, ListEnd: end}
+		var name strings.Builder
+		for i < close && p.text(i) != "(" && p.text(i) != "," {
+			name.WriteString(p.text(i))
+			a.End = p.Tokens[i].End
+			i++
+		}
+		a.Name = name.String()
+		if p.text(i) == "(" {
+			next := p.skip(i, close)
+			if next-1 > i {
+				a.Args = p.Source[p.Tokens[i].End:p.Tokens[next-1].Start]
+				a.End = p.Tokens[next-1].End
+			}
+			i = next
+		}
+		attrs = append(attrs, a)
+		i++ // ","
+	}
+	return attrs
+}
+
+// typeDecl parses a type declaration whose keyword is at kw. It returns the index after it.
+func (p *parser) typeDecl(first, kw, limit int, ns string, parent *Type, attrs []Attribute, mods []string) int {
+	t := &Type{Kind: p.text(kw), Namespace: ns, Parent: parent, Modifiers: mods, Attributes: attrs, Open: -1, Close: -1}
+	i := kw + 1
+	if t.Kind == "record" && (p.text(i) == "class" || p.text(i) == "struct") {
+		i++
+	}
+	t.Name = p.text(i)
+	i++
+
+	// Type parameters, primary constructor, base list and constraints up to the body or ";"
+	inBases := false
+	var base strings.Builder
+	for i < limit && p.text(i) != "{" && p.text(i) != ";" {
+		switch tok := p.text(i); {
+		case tok == "(" || tok == "[":
+			if inBases {
+				base.WriteString(p.Source[p.Tokens[i].Start:p.Tokens[p.skip(i, limit)-1].End])
+			}
+			i = p.skip(i, limit)
+			continue
+		case tok == ":" && !inBases:
+			inBases = true
+		case tok == "where":
+			inBases = false
+		case tok == "," && inBases && angleDepth(base.String()) == 0:
+			t.Bases = append(t.Bases, base.String())
+			base.Reset()
+		case inBases:
+			base.WriteString(tok)
+		}
+		i++
+	}
+	if base.Len() > 0 {
+		t.Bases = append(t.Bases, base.String())
+	}
+
+	end := i + 1
+	if p.text(i) == "{" {
+		close := p.skip(i, limit) - 1
+		t.Open, t.Close = p.Tokens[i].Start, p.Tokens[min(close, len(p.Tokens)-1)].Start
+		p.Types = append(p.Types, t)
+		p.block(i+1, close, ns, t)
+		end = close + 1
+		if p.text(end) == ";" {
+			end++
+		}
+	} else {
+		p.Types = append(p.Types, t)
+	}
+	t.Start = p.declStart(first)
+	t.End = p.lineEnd(p.Tokens[min(end, len(p.Tokens))-1].End)
+
+	p.addMember(parent, Member{Kind: Other, Name: t.Name, Modifiers: mods, Attributes: attrs, Open: t.Open, Close: t.Close}, first, end)
+	return end
+}
+
+func angleDepth(s string) int {
+	return strings.Count(s, "<") - strings.Count(s, ">")
+}
+
+// member parses a field, property, method, constructor or other member starting at i.
+func (p *parser) member(first, i, limit int, parent *Type, attrs []Attribute, mods []string) int {
+	m := Member{Kind: Field, Modifiers: mods, Attributes: attrs, Open: -1, Close: -1}
+
+	// The first "(" at depth 0 before "{", "=>", "=" or ";" makes it a method-like member
+	sigStart := i
+	j := i
+	for j < limit {
+		tok := p.text(j)
+		if tok == "{" || tok == "=>" || tok == "=" || tok == ";" {
+			break
+		}
+		// A parameter list follows the member name; other parentheses are tuple types
+		if tok == "(" && j > sigStart && (p.Tokens[j-1].Kind == Ident || p.text(j-1) == ">") {
+			break
+		}
+		if tok == "(" || tok == "[" {
+			j = p.skip(j, limit)
+			continue
+		}
+		j++
+	}
+
+	switch p.text(j) {
+	case "(":
+		m.Kind = Method
+		nameIdx := j - 1
+		if p.text(nameIdx) == ">" {
+			// Generic method: skip back over the type parameters
+			for depth := 0; nameIdx > sigStart; nameIdx-- {
+				if p.text(nameIdx) == ">" {
+					depth++
+				} else if p.text(nameIdx) == "<" {
+					depth--
+					if depth == 0 {
+						nameIdx--
+						break
+					}
+				}
+			}
+		}
+		m.Name = p.text(nameIdx)
+		if nameIdx > sigStart {
+			m.ReturnType = strings.TrimSpace(p.Source[p.Tokens[sigStart].Start:p.Tokens[nameIdx-1].End])
+		}
+		if m.ReturnType == "" && parent != nil && m.Name == parent.Name {
+			m.Kind = Constructor
+		}
+		if strings.HasSuffix(m.ReturnType, "operator") || m.ReturnType == "implicit operator" || m.ReturnType == "explicit operator" {
+			m.Kind = Other
+		}
+		close := p.skip(j, limit) - 1
+		if close > j {
+			m.Params = strings.TrimSpace(p.Source[p.Tokens[j].End:p.Tokens[close].Start])
+		}
+		j = close + 1
+		// Constructor initializer or generic constraints
+		for j < limit && p.text(j) != "{" && p.text(j) != "=>" && p.text(j) != ";" {
+			if p.match[j] > j {
+				j = p.match[j]
+			}
+			j++
+		}
+	case "{", "=>":
+		m.Kind = Property
+		m.Name = p.text(j - 1)
+		if m.Name == "]" {
+			m.Kind, m.Name = Other, "this" // Indexer
+		}
+		if j-1 > sigStart {
+			m.ReturnType = strings.TrimSpace(p.Source[p.Tokens[sigStart].Start:p.Tokens[j-2].End])
+		}
+	default:
+		m.Name = p.text(j - 1)
+		if j-1 > sigStart {
+			m.ReturnType = strings.TrimSpace(p.Source[p.Tokens[sigStart].Start:p.Tokens[j-2].End])
+		}
+	}
+
+	end := j
+	switch p.text(j) {
+	case "{":
+		close := p.skip(j, limit) - 1
+		if m.Kind == Method || m.Kind == Constructor {
+			m.Open, m.Close = p.Tokens[j].Start, p.Tokens[min(close, len(p.Tokens)-1)].Start
+		}
+		end = close + 1
+		// Property initializer: "public List<int> Ids { get; set; } = [];"
+		if m.Kind == Property && p.text(end) == "=" {
+			end = p.until(end, limit, ";") + 1
+		}
+	default:
+		end = p.until(j, limit, ";") + 1
+	}
+	end = min(end, limit)
+	p.addMember(parent, m, first, end)
+	return end
+}
+
+func (p *parser) addMember(parent *Type, m Member, first, end int) {
+	if parent == nil {
+		return
+	}
+	m.First, m.Last = first, end-1
+	m.Decl = p.Tokens[first].Start
+	if n := len(m.Attributes); n > 0 {
+		for i := first; i < end; i++ {
+			if p.Tokens[i].Start >= m.Attributes[n-1].ListEnd {
+				m.Decl = p.Tokens[i].Start
+				break
+			}
+		}
+	}
+	m.Start = p.declStart(first)
+	m.End = p.lineEnd(p.Tokens[end-1].End)
+	parent.Members = append(parent.Members, m)
+}
+
+// declStart returns the start of the line of token i, moved up over "///" doc comment lines.
+// If other code precedes the token on its line, the token start is returned.
+func (p *parser) declStart(i int) int {
+	off := p.Tokens[i].Start
+	start := LineStart(p.Source, off)
+	if strings.TrimSpace(p.Source[start:off]) != "" {
+		return off
+	}
+	for start > 0 {
+		prev := LineStart(p.Source, start-1)
+		if !strings.HasPrefix(strings.TrimSpace(p.Source[prev:start]), "///") {
+			break
+		}
+		start = prev
+	}
+	return start
+}
+
+// lineEnd returns the offset after the newline ending the line at off, if only whitespace or a
+// comment follows.
+func (p *parser) lineEnd(off int) int {
+	rest := p.Source[off:]
+	trimmed := strings.TrimLeft(rest, " \t\r")
+	if strings.HasPrefix(trimmed, "//") {
+		// Trailing comment: "private int count; // Cached"
+		trimmed = trimmed[strings.IndexByte(trimmed+"\n", '\n'):]
+	}
+	if strings.HasPrefix(trimmed, "\n") {
+		return off + len(rest) - len(trimmed) + 1
+	}
+	if trimmed == "" {
+		return len(p.Source)
+	}
+	return off
+}
+
+// LineStart returns the offset of the start of the line containing off.
+func LineStart(src string, off int) int {
+	return strings.LastIndex(src[:off], "\n") + 1
+}
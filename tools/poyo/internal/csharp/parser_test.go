@@ -0,0 +1,186 @@
+package csharp
+
+import (
+	"reflect"
+	"testing"
+)
+
+// member summarizes a parsed member for comparison.
+type member struct {
+	Kind       MemberKind
+	Name       string
+	ReturnType string
+	Modifiers  []string
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		types []string            // Type names in source order
+		want  map[string][]member // Members by type name
+	}{
+		{
+			name: "file-scoped namespace with a trailing helper class",
+			src: `using Microsoft.AspNetCore.Mvc;
+
+namespace Poyo.Server.Controllers;
+
+public class OrdersController : Controller
+{
+    public IActionResult Index()
+    {
+        return View();
+    }
+}
+
+internal static class OrdersHelper
+{
+    public static string Name() => "Orders";
+}
+`,
+			types: []string{"OrdersController", "OrdersHelper"},
+			want: map[string][]member{
+				"OrdersController": {{Method, "Index", "IActionResult", []string{"public"}}},
+				"OrdersHelper":     {{Method, "Name", "string", []string{"public", "static"}}},
+			},
+		},
+		{
+			name: "braces in comments and strings",
+			src: `namespace App
+{
+    public class HomeController : Controller
+    {
+        // Closing } in a comment
+        /* and { in a block comment */
+        public IActionResult Index()
+        {
+            var a = "}";
+            var b = $"{{ {Name} }}";
+            var c = @"""}""";
+            var d = """
+                }
+                """;
+            var e = '}';
+            return View();
+        }
+
+        public IActionResult About() => View();
+    }
+}
+`,
+			types: []string{"HomeController"},
+			want: map[string][]member{
+				"HomeController": {
+					{Method, "Index", "IActionResult", []string{"public"}},
+					{Method, "About", "IActionResult", []string{"public"}},
+				},
+			},
+		},
+		{
+			name: "async and generic return types",
+			src: `public class ApiController : ControllerBase
+{
+    private readonly IService _service;
+
+    public ApiController(IService service) => _service = service;
+
+    public string Name { get; set; } = "";
+
+    [HttpGet("{id}")]
+    public async Task<IActionResult> Get(int id)
+    {
+        return Ok(await _service.Get(id));
+    }
+
+    public ActionResult<List<Order>> List() => new List<Order>();
+
+    public async Task<ActionResult<Dictionary<string, int>>> Counts(CancellationToken ct) => new();
+}
+`,
+			types: []string{"ApiController"},
+			want: map[string][]member{
+				"ApiController": {
+					{Field, "_service", "IService", []string{"private", "readonly"}},
+					{Constructor, "ApiController", "", []string{"public"}},
+					{Property, "Name", "string", []string{"public"}},
+					{Method, "Get", "Task<IActionResult>", []string{"public", "async"}},
+					{Method, "List", "ActionResult<List<Order>>", []string{"public"}},
+					{Method, "Counts", "Task<ActionResult<Dictionary<string, int>>>", []string{"public", "async"}},
+				},
+			},
+		},
+		{
+			name: "nested types and positional records",
+			src: `namespace App;
+
+public record Point(int X, int Y);
+
+public class Outer
+{
+    public class Inner
+    {
+        public void Run() { }
+    }
+
+    public void Go() { }
+}
+`,
+			types: []string{"Point", "Outer", "Inner"},
+			want: map[string][]member{
+				"Point": nil,
+				"Outer": {
+					{Other, "Inner", "", []string{"public"}},
+					{Method, "Go", "void", []string{"public"}},
+				},
+				"Inner": {{Method, "Run", "void", []string{"public"}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := Parse(tt.src)
+			var names []string
+			for _, ty := range f.Types {
+				names = append(names, ty.Name)
+			}
+			if !reflect.DeepEqual(names, tt.types) {
+				t.Fatalf("types = %v, want %v", names, tt.types)
+			}
+			for name, want := range tt.want {
+				var got []member
+				for _, m := range f.Type(name).Members {
+					got = append(got, member{m.Kind, m.Name, m.ReturnType, m.Modifiers})
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%s members = %+v, want %+v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseOffsets(t *testing.T) {
+	src := "namespace App;\n\npublic class A\n{\n    /// <summary>Doc</summary>\n    [HttpGet]\n    public IActionResult Index() { return View(\"}\"); }\n}\n\nclass B { }\n"
+	f := Parse(src)
+	a := f.Type("A")
+	if a == nil || a.Namespace != "App" || a.Bases != nil {
+		t.Fatalf("A = %+v", a)
+	}
+	if got := src[a.Open : a.Close+1]; got[0] != '{' || got[len(got)-1] != '}' || len(got) != len("{\n    /// <summary>Doc</summary>\n    [HttpGet]\n    public IActionResult Index() { return View(\"}\"); }\n}") {
+		t.Errorf("A body = %q", got)
+	}
+	m, ok := a.Method("index")
+	if !ok {
+		t.Fatal("Index not found")
+	}
+	if got := src[m.Start:m.End]; got != "    /// <summary>Doc</summary>\n    [HttpGet]\n    public IActionResult Index() { return View(\"}\"); }\n" {
+		t.Errorf("Index = %q", got)
+	}
+	if !m.HasAttribute("HttpGet") || !m.HasModifier("public") {
+		t.Errorf("Index attributes %+v, modifiers %v", m.Attributes, m.Modifiers)
+	}
+	if b := f.Type("B"); b == nil || b.Namespace != "App" || src[b.Open:b.Close+1] != "{ }" {
+		t.Errorf("B = %+v", b)
+	}
+}
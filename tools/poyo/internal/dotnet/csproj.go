@@ -0,0 +1,138 @@
+// Package dotnet reads the server project's .csproj to name generated C# code after it.
+package dotnet
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+
+	"poyo-cli/internal/config"
+)
+
+// Project holds the .csproj properties that generated code depends on.
+type Project struct {
+	Path            string // The .csproj file, empty if none was found
+	Dir             string // The project directory
+	AssemblyName    string // e.g. "Poyo.Server"
+	RootNamespace   string // e.g. "Poyo.Server"
+	TargetFramework string // e.g. "net10.0", empty if not set
+}
+
+type csproj struct {
+	PropertyGroups []struct {
+		AssemblyName    string `xml:"AssemblyName"`
+		RootNamespace   string `xml:"RootNamespace"`
+		TargetFramework string `xml:"TargetFramework"`
+	} `xml:"PropertyGroup"`
+}
+
+// Load reads the .csproj in dir. Without one, the names default to the directory name like
+// MSBuild defaults them to the project file name.
+func Load(dir string) (Project, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.csproj"))
+	if err != nil {
+		return Project{}, err
+	}
+	name := filepath.Base(dir)
+	p := Project{Dir: dir}
+	if len(matches) > 1 {
+		return p, fmt.Errorf("%s contains more than one .csproj", dir)
+	}
+	if len(matches) == 1 {
+		p.Path = matches[0]
+		name = strings.TrimSuffix(filepath.Base(p.Path), ".csproj")
+
+		data, err := os.ReadFile(p.Path)
+		if err != nil {
+			return p, err
+		}
+		var proj csproj
+		if err := xml.Unmarshal(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), &proj); err != nil {
+			return p, fmt.Errorf("%s: %w", filepath.Base(p.Path), err)
+		}
+		// The last definition wins, as in MSBuild
+		for _, g := range proj.PropertyGroups {
+			if v := strings.TrimSpace(g.AssemblyName); v != "" {
+				p.AssemblyName = v
+			}
+			if v := strings.TrimSpace(g.RootNamespace); v != "" {
+				p.RootNamespace = v
+			}
+			if v := strings.TrimSpace(g.TargetFramework); v != "" {
+				p.TargetFramework = v
+			}
+		}
+	}
+
+	p.AssemblyName = strings.ReplaceAll(p.AssemblyName, "$(MSBuildProjectName)", name)
+	if p.AssemblyName == "" {
+		p.AssemblyName = name
+	}
+	p.RootNamespace = strings.NewReplacer("$(MSBuildProjectName)", name, "$(AssemblyName)", p.AssemblyName).Replace(p.RootNamespace)
+	if p.RootNamespace == "" {
+		p.RootNamespace = strings.ReplaceAll(name, " ", "_")
+	}
+	return p, nil
+}
+
+var server struct {
+	once sync.Once
+	p    Project
+	err  error
+}
+
+// Server returns the server project (config.ServerDir), read once.
+func Server() (Project, error) {
+	server.once.Do(func() {
+		server.p, server.err = Load(config.ServerDir)
+	})
+	return server.p, server.err
+}
+
+// ServerNamespace returns the namespace of code in dir, a directory of the server project.
+// If the .csproj cannot be read, the directory name of the project is used as the root namespace.
+func ServerNamespace(dir string) string {
+	p, err := Server()
+	if err != nil && p.RootNamespace == "" {
+		p.Dir, p.RootNamespace = config.ServerDir, Identifier(filepath.Base(config.ServerDir))
+	}
+	return p.Namespace(dir)
+}
+
+// Namespace returns the namespace of code in dir: the root namespace followed by the folders
+// below the project directory, the way Visual Studio names new files.
+func (p Project) Namespace(dir string) string {
+	parts := []string{p.RootNamespace}
+	if rel, err := filepath.Rel(p.Dir, dir); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+		for _, folder := range strings.Split(filepath.ToSlash(rel), "/") {
+			for _, segment := range strings.Split(folder, ".") {
+				parts = append(parts, Identifier(segment))
+			}
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// Identifier makes s a valid C# identifier: invalid characters become "_", and a leading digit
+// gets a "_" prefix. Dots are kept, so "My App.Server" gives "My_App.Server".
+func Identifier(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '.' || r == '_' || unicode.IsLetter(r):
+		case unicode.IsDigit(r):
+			if i == 0 {
+				b.WriteByte('_')
+			}
+		default:
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,423 @@
+// Package editorconfig reads .editorconfig files so that generated and rewritten files follow the
+// project's indentation, line endings, final newline and charset (https://editorconfig.org).
+package editorconfig
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Style holds the properties that apply to a file. Empty fields were not set by any .editorconfig.
+type Style struct {
+	IndentStyle            string // "tab" or "space"
+	IndentSize             int    // Columns of one level of indentation
+	EndOfLine              string // "lf", "crlf" or "cr"
+	Charset                string // "utf-8", "utf-8-bom", ...
+	InsertFinalNewline     *bool
+	TrimTrailingWhitespace *bool
+}
+
+const bom = "\xef\xbb\xbf"
+
+type section struct {
+	pattern *regexp.Regexp
+	props   map[string]string
+}
+
+type file struct {
+	root     bool
+	sections []section
+}
+
+// parsed caches .editorconfig files by path; nil when a directory has none.
+var parsed = make(map[string]*file)
+
+// For returns the style of name, a file that may not exist yet, from the .editorconfig files in its
+// directory and above, up to the one declaring root = true.
+func For(name string) Style {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return Style{}
+	}
+
+	// Closest first; applied from the root down so closer files win
+	var dirs []string
+	var files []*file
+	for dir := filepath.Dir(abs); ; {
+		if f := load(filepath.Join(dir, ".editorconfig")); f != nil {
+			dirs, files = append(dirs, dir), append(files, f)
+			if f.root {
+				break
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	props := make(map[string]string)
+	for i := len(files) - 1; i >= 0; i-- {
+		rel, err := filepath.Rel(dirs[i], abs)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		for _, s := range files[i].sections {
+			if s.pattern.MatchString(rel) {
+				for k, v := range s.props {
+					props[k] = v
+				}
+			}
+		}
+	}
+	return newStyle(props)
+}
+
+func newStyle(props map[string]string) Style {
+	var s Style
+	get := func(key string) string {
+		if v := props[key]; v != "unset" {
+			return v
+		}
+		return ""
+	}
+	boolean := func(key string) *bool {
+		switch get(key) {
+		case "true":
+			b := true
+			return &b
+		case "false":
+			b := false
+			return &b
+		}
+		return nil
+	}
+
+	switch v := get("indent_style"); v {
+	case "tab", "space":
+		s.IndentStyle = v
+	}
+	tabWidth, _ := strconv.Atoi(get("tab_width"))
+	switch v := get("indent_size"); v {
+	case "tab":
+		s.IndentSize = tabWidth
+	default:
+		s.IndentSize, _ = strconv.Atoi(v)
+	}
+	if s.IndentSize <= 0 {
+		s.IndentSize = tabWidth
+	}
+	switch v := get("end_of_line"); v {
+	case "lf", "crlf", "cr":
+		s.EndOfLine = v
+	}
+	s.Charset = get("charset")
+	s.InsertFinalNewline = boolean("insert_final_newline")
+	s.TrimTrailingWhitespace = boolean("trim_trailing_whitespace")
+	return s
+}
+
+// load parses an .editorconfig file, nil if there is none.
+func load(path string) *file {
+	if f, ok := parsed[path]; ok {
+		return f
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		parsed[path] = nil
+		return nil
+	}
+
+	f := &file{}
+	var current *section
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(data, []byte(bom))))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' && line[len(line)-1] == ']' {
+			f.sections = append(f.sections, section{pattern: globRegexp(line[1 : len(line)-1]), props: make(map[string]string)})
+			current = &f.sections[len(f.sections)-1]
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.ToLower(strings.TrimSpace(value))
+		if current == nil {
+			if key == "root" {
+				f.root = value == "true"
+			}
+			continue
+		}
+		current.props[key] = value
+	}
+	parsed[path] = f
+	return f
+}
+
+// globRegexp converts a section name to a regexp matching paths relative to the .editorconfig
+// directory. A name without "/" matches files in any subdirectory.
+func globRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	if strings.HasPrefix(glob, "/") {
+		glob = glob[1:]
+	} else if !strings.Contains(glob, "/") {
+		b.WriteString("(?:.*/)?")
+	}
+
+	braces := 0
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+			}
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end
+		case '{':
+			end := strings.IndexByte(glob[i:], '}')
+			if end > 0 {
+				if r := numericRange(glob[i+1 : i+end]); r != "" {
+					b.WriteString(r)
+					i += end
+					continue
+				}
+			}
+			if end < 0 || !strings.Contains(glob[i:i+end], ",") {
+				b.WriteString(`\{`)
+				continue
+			}
+			braces++
+			b.WriteString("(?:")
+		case '}':
+			if braces == 0 {
+				b.WriteString(`\}`)
+				continue
+			}
+			braces--
+			b.WriteString(")")
+		case ',':
+			if braces == 0 {
+				b.WriteString(",")
+				continue
+			}
+			b.WriteString("|")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return regexp.MustCompile(`^$.`) // Matches nothing
+	}
+	return re
+}
+
+var rangeRe = regexp.MustCompile(`^([+-]?\d+)\.\.([+-]?\d+)$`)
+
+// numericRange converts "{1..3}" (without braces) to "(?:1|2|3)", empty if s is not a range.
+func numericRange(s string) string {
+	m := rangeRe.FindStringSubmatch(s)
+	if m == nil {
+		return ""
+	}
+	lo, _ := strconv.Atoi(m[1])
+	hi, _ := strconv.Atoi(m[2])
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if hi-lo > 1000 {
+		return `[+-]?\d+`
+	}
+	var alts []string
+	for n := lo; n <= hi; n++ {
+		alts = append(alts, strconv.Itoa(n))
+	}
+	return "(?:" + strings.Join(alts, "|") + ")"
+}
+
+// Indent returns one level of indentation, empty if the style does not set it.
+func (s Style) Indent() string {
+	switch {
+	case s.IndentStyle == "tab":
+		return "\t"
+	case s.IndentStyle == "space" && s.IndentSize > 0:
+		return strings.Repeat(" ", s.IndentSize)
+	}
+	return ""
+}
+
+// Newline returns the line ending of the style, empty if it does not set one.
+func (s Style) Newline() string {
+	switch s.EndOfLine {
+	case "lf":
+		return "\n"
+	case "crlf":
+		return "\r\n"
+	case "cr":
+		return "\r"
+	}
+	return ""
+}
+
+// Format applies the style to generated content. Indentation is converted from the unit content is
+// written with (a tab, or the smallest indentation of its lines), keeping alignment spaces such as
+// the " * " of block comments.
+func (s Style) Format(content string) string {
+	content = strings.TrimPrefix(content, bom)
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	lines := strings.Split(content, "\n")
+
+	if target := s.Indent(); target != "" {
+		if source := indentUnit(lines); source != "" && source != target {
+			for i, line := range lines {
+				level := 0
+				for strings.HasPrefix(line, source) {
+					line, level = line[len(source):], level+1
+				}
+				lines[i] = strings.Repeat(target, level) + line
+			}
+		}
+	}
+	if s.TrimTrailingWhitespace != nil && *s.TrimTrailingWhitespace {
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " \t")
+		}
+	}
+
+	content = strings.Join(lines, "\n")
+	if s.InsertFinalNewline != nil {
+		content = strings.TrimRight(content, "\n")
+		if *s.InsertFinalNewline && content != "" {
+			content += "\n"
+		}
+	}
+	if nl := s.Newline(); nl != "" && nl != "\n" {
+		content = strings.ReplaceAll(content, "\n", nl)
+	}
+	if s.Charset == "utf-8-bom" {
+		content = bom + content
+	}
+	return content
+}
+
+// indentUnit guesses the indentation unit of lines: a tab if any line starts with one, otherwise the
+// smallest space indentation, ignoring the continuation lines of block comments.
+func indentUnit(lines []string) string {
+	unit := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, "\t") {
+			return "\t"
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		n := len(line) - len(trimmed)
+		if n == 0 || trimmed == "" || strings.HasPrefix(trimmed, "*") {
+			continue
+		}
+		if unit == 0 || n < unit {
+			unit = n
+		}
+	}
+	return strings.Repeat(" ", unit)
+}
+
+// WriteFile writes content generated by poyo (a new file, or one it rewrites entirely such as
+// routes.json) formatted with the style of name.
+func WriteFile(name, content string) error {
+	return os.WriteFile(name, []byte(For(name).Format(content)), 0644)
+}
+
+// RewriteFile writes a file poyo regenerates entirely, such as routes.json. An existing file keeps
+// its own indentation, line endings, final newline and byte order mark, so that only changed entries
+// show in diffs; a new one is written with WriteFile.
+func RewriteFile(name, content string) error {
+	original, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return WriteFile(name, content)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, []byte(Detect(string(original)).Format(content)), 0644)
+}
+
+// Detect returns the style content is written in. Properties it cannot tell, such as the
+// indentation of a file without indented lines, are left empty.
+func Detect(content string) Style {
+	var s Style
+	if strings.HasPrefix(content, bom) {
+		s.Charset = "utf-8-bom"
+	}
+	switch {
+	case strings.Contains(content, "\r\n"):
+		s.EndOfLine = "crlf"
+	case strings.Contains(content, "\n"):
+		s.EndOfLine = "lf"
+	}
+	switch unit := indentUnit(strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")); {
+	case unit == "\t":
+		s.IndentStyle = "tab"
+	case unit != "":
+		s.IndentStyle, s.IndentSize = "space", len(unit)
+	}
+	if content != "" {
+		final := strings.HasSuffix(content, "\n")
+		s.InsertFinalNewline = &final
+	}
+	return s
+}
+
+// UpdateFile writes an edited existing file. The file keeps its own line endings and byte order
+// mark, so that only the edited lines change; new code is expected to already match its indentation.
+// A file that does not exist yet is written with WriteFile.
+func UpdateFile(name, content string) error {
+	original, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return WriteFile(name, content)
+	}
+	if err != nil {
+		return err
+	}
+	content = strings.ReplaceAll(strings.TrimPrefix(content, bom), "\r\n", "\n")
+	if bytes.Contains(original, []byte("\r\n")) {
+		content = strings.ReplaceAll(content, "\n", "\r\n")
+	}
+	if bytes.HasPrefix(original, []byte(bom)) {
+		content = bom + content
+	}
+	return os.WriteFile(name, []byte(content), 0644)
+}
@@ -0,0 +1,118 @@
+package editorconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGlobRegexp(t *testing.T) {
+	tests := []struct {
+		glob  string
+		path  string
+		match bool
+	}{
+		{"*", "a.ts", true},
+		{"*", "src/a.ts", true},
+		{"*.ts", "src/pages/a.ts", true},
+		{"*.ts", "a.tsx", false},
+		{"/*.json", "routes.json", true},
+		{"/*.json", "src/routes.json", false},
+		{"src/*.ts", "src/a.ts", true},
+		{"src/*.ts", "src/lib/a.ts", false},
+		{"src/**.ts", "src/lib/a.ts", true},
+		{"**/Views/*.cshtml", "Poyo.Server/Views/Home.cshtml", true},
+		{"a?.ts", "ab.ts", true},
+		{"a?.ts", "a/.ts", false},
+		{"*.{ts,tsx}", "a.tsx", true},
+		{"*.{ts,tsx}", "a.js", false},
+		{"*.{json}", "a.{json}", true}, // No comma: literal braces
+		{"file{1..3}.txt", "file2.txt", true},
+		{"file{1..3}.txt", "file4.txt", false},
+		{"[abc].ts", "b.ts", true},
+		{"[!abc].ts", "b.ts", false},
+		{"[!abc].ts", "d.ts", true},
+		{`\*.ts`, "*.ts", true},
+		{`\*.ts`, "a.ts", false},
+		{"{*.cs,*.csproj}", "src/A.csproj", true},
+	}
+	for _, tt := range tests {
+		if got := globRegexp(tt.glob).MatchString(tt.path); got != tt.match {
+			t.Errorf("globRegexp(%q) matching %q = %v, want %v (%s)", tt.glob, tt.path, got, tt.match, globRegexp(tt.glob))
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	yes := true
+	tests := []struct {
+		name    string
+		style   Style
+		content string
+		want    string
+	}{
+		{"tabs to spaces", Style{IndentStyle: "space", IndentSize: 2}, "a {\n\tb {\n\t\tc;\n\t}\n}\n", "a {\n  b {\n    c;\n  }\n}\n"},
+		{"spaces to tabs", Style{IndentStyle: "tab"}, "a {\n    b;\n}\n", "a {\n\tb;\n}\n"},
+		{"block comment", Style{IndentStyle: "space", IndentSize: 2}, "\t/**\n\t * Doc\n\t */\n\tx;\n", "  /**\n   * Doc\n   */\n  x;\n"},
+		{"crlf", Style{EndOfLine: "crlf"}, "a\nb\n", "a\r\nb\r\n"},
+		{"final newline", Style{InsertFinalNewline: &yes}, "a\n\n\n", "a\n"},
+		{"trim", Style{TrimTrailingWhitespace: &yes}, "a  \nb\t\n", "a\nb\n"},
+		{"bom", Style{Charset: "utf-8-bom"}, "a\n", bom + "a\n"},
+	}
+	for _, tt := range tests {
+		if got := tt.style.Format(tt.content); got != tt.want {
+			t.Errorf("%s: Format() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRewriteFile(t *testing.T) {
+	dir := t.TempDir()
+	config := "root = true\n\n[*.json]\nindent_style = space\nindent_size = 2\nend_of_line = crlf\ninsert_final_newline = true\n"
+	if err := os.WriteFile(filepath.Join(dir, ".editorconfig"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	generated := "[\n\t{\n\t\t\"path\": \"/\"\n\t}\n]\n"
+
+	tests := []struct {
+		name     string
+		existing string // Empty: no file yet
+		want     string
+	}{
+		{"new file follows .editorconfig", "", "[\r\n  {\r\n    \"path\": \"/\"\r\n  }\r\n]\r\n"},
+		{"tabs and lf kept", "[\n\t{}\n]\n", "[\n\t{\n\t\t\"path\": \"/\"\n\t}\n]\n"},
+		{"4 spaces kept", "[\n    {}\n]", "[\n    {\n        \"path\": \"/\"\n    }\n]"},
+		{"bom kept", bom + "[\r\n  {}\r\n]\r\n", bom + "[\r\n  {\r\n    \"path\": \"/\"\r\n  }\r\n]\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, "routes.json")
+			os.Remove(file)
+			if tt.existing != "" {
+				if err := os.WriteFile(file, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := RewriteFile(file, generated); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := os.ReadFile(file); string(got) != tt.want {
+				t.Errorf("RewriteFile() wrote %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "index.ts")
+	if err := os.WriteFile(file, []byte("export { a } from \"./a\";\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateFile(file, "export { a } from \"./a\";\r\nexport { b } from \"./b\";\r\n"); err != nil {
+		t.Fatal(err)
+	}
+	want := "export { a } from \"./a\";\nexport { b } from \"./b\";\n"
+	if got, _ := os.ReadFile(file); string(got) != want {
+		t.Errorf("UpdateFile() wrote %q, want %q", got, want)
+	}
+}
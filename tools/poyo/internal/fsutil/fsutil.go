@@ -4,9 +4,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"poyo-cli/internal/config"
 )
 
 // FindFiles recursively looks for files in dir that satisfy the predicate.
+// Paths matching the project's .poyoignore are skipped.
 // Returns a list of paths relative to rootDir.
 func FindFiles(dir string, predicate func(string) bool, rootDir string) ([]string, error) {
 	var fileList []string
@@ -16,10 +19,21 @@ func FindFiles(dir string, predicate func(string) bool, rootDir string) ([]strin
 		return fileList, nil
 	}
 
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+	ignore, err := LoadIgnore(config.IgnoreFile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if ignore.Match(RelToRoot(path)) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if info.IsDir() {
 			return nil
 		}
@@ -55,3 +69,13 @@ func DeleteEmptyParents(filePath string, rootDir string) {
 		}
 	}
 }
+
+// RelToRoot returns path relative to the project root, using forward slashes.
+// This is the form matched by .poyoignore patterns.
+func RelToRoot(path string) string {
+	rel, err := filepath.Rel(config.RootDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,180 @@
+package fsutil
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"poyo-cli/internal/editorconfig"
+)
+
+type ignoreRule struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// IgnoreList holds the glob patterns read from a .poyoignore file.
+// Syntax follows .gitignore loosely:
+//   - blank lines and lines starting with '#' are skipped
+//   - a leading '!' re-includes a previously ignored path
+//   - a trailing '/' matches a directory and everything below it
+//   - a pattern without '/' matches a file or folder name at any depth
+//   - '**' matches any number of path segments
+//
+// Paths are matched relative to the project root (e.g. "poyo.client/src/pages/Drafts/**").
+type IgnoreList struct {
+	rules []ignoreRule
+}
+
+// LoadIgnore reads the ignore file at path. A missing file yields an empty list.
+func LoadIgnore(path string) (*IgnoreList, error) {
+	list := &IgnoreList{}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return list, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		list.Add(scanner.Text())
+	}
+	return list, scanner.Err()
+}
+
+// Add parses a single pattern line and appends it to the list.
+func (l *IgnoreList) Add(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+
+	rule := ignoreRule{}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if strings.HasPrefix(line, "/") {
+		line = strings.TrimPrefix(line, "/")
+		rule.anchored = true
+	}
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+	}
+	rule.pattern = filepath.ToSlash(line)
+	l.rules = append(l.rules, rule)
+}
+
+// Match reports whether rel (a slash separated path relative to the project root) is ignored.
+func (l *IgnoreList) Match(rel string) bool {
+	if l == nil {
+		return false
+	}
+	rel = strings.TrimPrefix(filepath.ToSlash(rel), "./")
+	segments := strings.Split(rel, "/")
+
+	ignored := false
+	for _, rule := range l.rules {
+		if rule.matches(segments) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+func (r ignoreRule) matches(segments []string) bool {
+	patSegs := strings.Split(r.pattern, "/")
+
+	if !r.anchored {
+		// Match the name at any depth. A directory match covers everything below it.
+		for i, seg := range segments {
+			isLast := i == len(segments)-1
+			if r.dirOnly && isLast {
+				continue
+			}
+			if ok, _ := path.Match(r.pattern, seg); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Anchored: the pattern must match a prefix of the path (a directory) or the whole path.
+	for n := 1; n <= len(segments); n++ {
+		if r.dirOnly && n == len(segments) {
+			break
+		}
+		if matchSegments(patSegs, segments[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches glob segments against path segments, with '**' spanning zero or more segments.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// AppendIgnore adds patterns to the ignore file at path, creating it if needed.
+// Patterns already present are skipped.
+func AppendIgnore(path string, patterns ...string) error {
+	existing := make(map[string]bool)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+
+	var b strings.Builder
+	b.Write(data)
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		b.WriteString("\n")
+	}
+	added := 0
+	for _, p := range patterns {
+		p = filepath.ToSlash(strings.TrimSpace(p))
+		if p == "" || existing[p] {
+			continue
+		}
+		existing[p] = true
+		b.WriteString(p + "\n")
+		added++
+	}
+	if added == 0 {
+		return nil
+	}
+
+	return editorconfig.UpdateFile(path, b.String())
+}
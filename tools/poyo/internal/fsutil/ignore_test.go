@@ -0,0 +1,82 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnoreMatch(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		path     string
+		want     bool
+	}{
+		// Names match at any depth, files and folders alike
+		{[]string{"*.draft.tsx"}, "poyo.client/src/pages/Home/index.draft.tsx", true},
+		{[]string{"*.draft.tsx"}, "poyo.client/src/pages/Home/index.page.tsx", false},
+		{[]string{"Drafts"}, "poyo.client/src/pages/Drafts/index.page.tsx", true},
+		{[]string{"Drafts"}, "poyo.client/src/pages/MyDrafts/index.page.tsx", false},
+		// A trailing slash matches folders only
+		{[]string{"Drafts/"}, "poyo.client/src/pages/Drafts/index.page.tsx", true},
+		{[]string{"Drafts/"}, "poyo.client/src/pages/Drafts", false},
+		// Patterns with a slash are anchored to the project root
+		{[]string{"Poyo.Server/Views/Shared"}, "Poyo.Server/Views/Shared/_Layout.cshtml", true},
+		{[]string{"Views/Shared"}, "Poyo.Server/Views/Shared/_Layout.cshtml", false},
+		{[]string{"/routes.json"}, "routes.json", true},
+		{[]string{"/routes.json"}, "sub/routes.json", false},
+		{[]string{"poyo.client/src/pages/*/Partial.page.tsx"}, "poyo.client/src/pages/Home/Partial.page.tsx", true},
+		{[]string{"poyo.client/src/pages/*/Partial.page.tsx"}, "poyo.client/src/pages/A/B/Partial.page.tsx", false},
+		// ** spans any number of segments, none included
+		{[]string{"poyo.client/**/Partial.page.tsx"}, "poyo.client/src/pages/A/B/Partial.page.tsx", true},
+		{[]string{"poyo.client/**/Partial.page.tsx"}, "poyo.client/Partial.page.tsx", true},
+		{[]string{"poyo.client/src/pages/Drafts/**"}, "poyo.client/src/pages/Drafts/A/index.page.tsx", true},
+		{[]string{"**/Admin"}, "Poyo.Server/Views/Admin/Index.cshtml", true},
+		// The last matching rule wins
+		{[]string{"Drafts/", "!Drafts/Keep.page.tsx"}, "poyo.client/src/pages/Drafts/Keep.page.tsx", true},
+		{[]string{"Drafts/*", "!Drafts/Keep.page.tsx"}, "Drafts/Keep.page.tsx", false},
+		{[]string{"*.tsx", "!index.page.tsx", "Secret"}, "src/Secret/index.page.tsx", true},
+		// Comments and blank lines are skipped; "./" is dropped
+		{[]string{"# Drafts", "", "  "}, "Drafts/a.tsx", false},
+		{[]string{"Drafts"}, "./Drafts/a.tsx", true},
+	}
+	for _, tt := range tests {
+		list := &IgnoreList{}
+		for _, p := range tt.patterns {
+			list.Add(p)
+		}
+		if got := list.Match(tt.path); got != tt.want {
+			t.Errorf("%q Match(%q) = %v, want %v", tt.patterns, tt.path, got, tt.want)
+		}
+	}
+
+	var none *IgnoreList
+	if none.Match("a") {
+		t.Error("nil list matched")
+	}
+}
+
+func TestAppendIgnore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".poyoignore")
+	if err := os.WriteFile(file, []byte("# Drafts\r\nDrafts/"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendIgnore(file, "Drafts/", "Views/Old.cshtml", "", " Views/Old.cshtml "); err != nil {
+		t.Fatal(err)
+	}
+	want := "# Drafts\r\nDrafts/\r\nViews/Old.cshtml\r\n"
+	if got, _ := os.ReadFile(file); string(got) != want {
+		t.Errorf("AppendIgnore() wrote %q, want %q", got, want)
+	}
+
+	list, err := LoadIgnore(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !list.Match("Views/Old.cshtml") || list.Match("Views/New.cshtml") {
+		t.Error("LoadIgnore() did not read the appended patterns")
+	}
+	if list, err := LoadIgnore(filepath.Join(t.TempDir(), "missing")); err != nil || list.Match("a") {
+		t.Errorf("LoadIgnore(missing) = %v, %v", list, err)
+	}
+}
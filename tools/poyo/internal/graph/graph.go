@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/routes"
+)
+
+type NodeKind string
+
+const (
+	PageNode       NodeKind = "page"
+	ControllerNode NodeKind = "controller"
+)
+
+type EdgeKind string
+
+const (
+	Serves   EdgeKind = "serves"
+	Redirect EdgeKind = "redirect"
+)
+
+type Node struct {
+	ID     string   `json:"id"`
+	Label  string   `json:"label"`
+	Kind   NodeKind `json:"kind"`
+	Group  string   `json:"group,omitempty"`
+	Access string   `json:"access,omitempty"`
+	Custom bool     `json:"custom,omitempty"`
+	// Missing marks a custom controller action that routes.json references but the source does not define.
+	Missing bool `json:"missing,omitempty"`
+}
+
+type Edge struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Kind  EdgeKind `json:"kind"`
+	Label string   `json:"label,omitempty"`
+}
+
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// AuthRedirects are the redirects applied by the server's auth setup.
+type AuthRedirects struct {
+	Login     string // Where anonymous users are sent from protected pages (cookie LoginPath)
+	GuestHome string // Where authenticated users are sent from guest-only pages (GuestOnlyAttribute)
+}
+
+var (
+	loginPathRe = regexp.MustCompile(`LoginPath\s*=\s*"([^"]+)"`)
+	redirectRe  = regexp.MustCompile(`RedirectResult\(\s*"([^"]+)"\s*\)`)
+	nonIDRe     = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// DiscoverAuthRedirects reads the login path from Program.cs and the guest-only redirect
+// from GuestOnlyAttribute.cs. Unknown targets are left empty.
+func DiscoverAuthRedirects(serverDir string) AuthRedirects {
+	var a AuthRedirects
+	if data, err := os.ReadFile(filepath.Join(serverDir, "Program.cs")); err == nil {
+		if m := loginPathRe.FindSubmatch(data); m != nil {
+			a.Login = string(m[1])
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(serverDir, "Middleware", "Auth", "GuestOnlyAttribute.cs")); err == nil {
+		if m := redirectRe.FindSubmatch(data); m != nil {
+			a.GuestHome = string(m[1])
+		}
+	}
+	return a
+}
+
+// Build creates the page/controller graph for the given routes.
+func Build(r []routes.Route, files []controllers.File, auth AuthRedirects) Graph {
+	g := Graph{Nodes: []Node{}, Edges: []Edge{}}
+
+	sorted := append([]routes.Route(nil), r...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	seen := make(map[string]bool)
+	addNode := func(n Node) {
+		if !seen[n.ID] {
+			seen[n.ID] = true
+			g.Nodes = append(g.Nodes, n)
+		}
+	}
+
+	for _, rt := range sorted {
+		page := PageID(rt.Path)
+		addNode(Node{ID: page, Label: rt.Path, Kind: PageNode, Group: Group(rt.Path), Access: routes.Access(rt)})
+
+		var ctrl, action string
+		custom := true
+		switch {
+		case rt.Controller != "":
+			ctrl, action = rt.Controller, rt.Action
+		case strings.EqualFold(rt.Name, "Home"):
+			// Program.cs skips Home when mapping routes.json; the default MVC route serves it
+			ctrl, action = "HomeController", "Index"
+		default:
+			ctrl, action, custom = "PageController", pageAction(rt), false
+		}
+		id := "ctrl_" + nonIDRe.ReplaceAllString(ctrl+"_"+action, "_")
+		addNode(Node{
+			ID:      id,
+			Label:   ctrl + "." + action,
+			Kind:    ControllerNode,
+			Custom:  custom,
+			Missing: custom && !hasAction(files, ctrl, action),
+		})
+		g.Edges = append(g.Edges, Edge{From: id, To: page, Kind: Serves})
+	}
+
+	// Auth redirects only point at pages that exist in routes.json
+	byPath := make(map[string]string)
+	for _, rt := range sorted {
+		byPath[strings.ToLower(rt.Path)] = PageID(rt.Path)
+	}
+	for _, rt := range sorted {
+		var target, label string
+		switch routes.Access(rt) {
+		case "protected":
+			target, label = auth.Login, "anonymous"
+		case "guest-only":
+			target, label = auth.GuestHome, "authenticated"
+		}
+		if to, ok := byPath[strings.ToLower(target)]; ok && target != "" && to != PageID(rt.Path) {
+			g.Edges = append(g.Edges, Edge{From: PageID(rt.Path), To: to, Kind: Redirect, Label: label})
+		}
+	}
+	return g
+}
+
+// PageID returns a node id that is valid in both Mermaid and DOT.
+func PageID(path string) string {
+	if path == "/" {
+		return "page_root"
+	}
+	return "page" + nonIDRe.ReplaceAllString(path, "_")
+}
+
+// Group returns the first path segment of a route, used to cluster nodes.
+func Group(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0]
+}
+
+func pageAction(rt routes.Route) string {
+	switch {
+	case rt.IsGuestOnly:
+		return "GuestIndex"
+	case rt.IsPublic:
+		return "PublicIndex"
+	default:
+		return "Index"
+	}
+}
+
+func hasAction(files []controllers.File, ctrl, action string) bool {
+	want := strings.TrimSuffix(ctrl, "Controller")
+	for _, f := range files {
+		if !strings.EqualFold(strings.TrimSuffix(f.Name, "Controller"), want) {
+			continue
+		}
+		if _, ok := controllers.Find(f.Actions, action); ok {
+			return true
+		}
+	}
+	return false
+}
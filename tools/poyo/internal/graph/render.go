@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Colors per access level, shared by the Mermaid and DOT renderers.
+var accessColors = map[string][2]string{
+	"protected":  {"#fde2e2", "#c0392b"},
+	"public":     {"#e2f7e2", "#27ae60"},
+	"guest-only": {"#fff4d6", "#d68910"},
+}
+
+const controllerFill, controllerStroke = "#e8eefc", "#2c3e50"
+
+// Mermaid renders the graph as a Mermaid flowchart.
+func Mermaid(g Graph) string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, access := range []string{"protected", "public", "guest-only"} {
+		c := accessColors[access]
+		fmt.Fprintf(&b, "    classDef %s fill:%s,stroke:%s\n", classOf(access), c[0], c[1])
+	}
+	fmt.Fprintf(&b, "    classDef controller fill:%s,stroke:%s\n", controllerFill, controllerStroke)
+	fmt.Fprintf(&b, "    classDef custom fill:%s,stroke:%s,stroke-width:2px\n", controllerFill, controllerStroke)
+	b.WriteString("    classDef missing fill:#ffffff,stroke:#c0392b,stroke-dasharray:4\n")
+
+	groups, ungrouped := groupPages(g)
+	for _, name := range sortedKeys(groups) {
+		fmt.Fprintf(&b, "    subgraph grp_%s[\"/%s\"]\n", nonIDRe.ReplaceAllString(name, "_"), name)
+		for _, n := range groups[name] {
+			fmt.Fprintf(&b, "        %s[\"%s\"]:::%s\n", n.ID, n.Label, classOf(n.Access))
+		}
+		b.WriteString("    end\n")
+	}
+	for _, n := range ungrouped {
+		fmt.Fprintf(&b, "    %s[\"%s\"]:::%s\n", n.ID, n.Label, classOf(n.Access))
+	}
+
+	for _, n := range g.Nodes {
+		if n.Kind != ControllerNode {
+			continue
+		}
+		fmt.Fprintf(&b, "    %s[[\"%s\"]]:::%s\n", n.ID, n.Label, controllerClass(n))
+	}
+
+	for _, e := range g.Edges {
+		switch e.Kind {
+		case Redirect:
+			fmt.Fprintf(&b, "    %s -. \"%s\" .-> %s\n", e.From, e.Label, e.To)
+		default:
+			fmt.Fprintf(&b, "    %s --> %s\n", e.From, e.To)
+		}
+	}
+	return b.String()
+}
+
+// DOT renders the graph in Graphviz DOT format.
+func DOT(g Graph) string {
+	var b strings.Builder
+	b.WriteString("digraph routes {\n")
+	b.WriteString("    rankdir=LR;\n")
+	b.WriteString("    node [style=filled, fontname=\"Helvetica\"];\n")
+
+	writePage := func(indent string, n Node) {
+		c := accessColors[n.Access]
+		fmt.Fprintf(&b, "%s%s [label=%q, shape=box, fillcolor=%q, color=%q];\n", indent, n.ID, n.Label, c[0], c[1])
+	}
+
+	groups, ungrouped := groupPages(g)
+	for _, name := range sortedKeys(groups) {
+		fmt.Fprintf(&b, "    subgraph cluster_%s {\n", nonIDRe.ReplaceAllString(name, "_"))
+		fmt.Fprintf(&b, "        label=%q;\n", "/"+name)
+		for _, n := range groups[name] {
+			writePage("        ", n)
+		}
+		b.WriteString("    }\n")
+	}
+	for _, n := range ungrouped {
+		writePage("    ", n)
+	}
+
+	for _, n := range g.Nodes {
+		if n.Kind != ControllerNode {
+			continue
+		}
+		style := "filled"
+		if n.Missing {
+			style = "dashed"
+		} else if n.Custom {
+			style = "filled,bold"
+		}
+		fmt.Fprintf(&b, "    %s [label=%q, shape=component, style=%q, fillcolor=%q, color=%q];\n",
+			n.ID, n.Label, style, controllerFill, controllerStroke)
+	}
+
+	for _, e := range g.Edges {
+		switch e.Kind {
+		case Redirect:
+			fmt.Fprintf(&b, "    %s -> %s [style=dashed, label=%q];\n", e.From, e.To, e.Label)
+		default:
+			fmt.Fprintf(&b, "    %s -> %s;\n", e.From, e.To)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func groupPages(g Graph) (map[string][]Node, []Node) {
+	groups := make(map[string][]Node)
+	var ungrouped []Node
+	for _, n := range g.Nodes {
+		if n.Kind != PageNode {
+			continue
+		}
+		if n.Group == "" {
+			ungrouped = append(ungrouped, n)
+		} else {
+			groups[n.Group] = append(groups[n.Group], n)
+		}
+	}
+	return groups, ungrouped
+}
+
+func sortedKeys(m map[string][]Node) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func classOf(access string) string {
+	return strings.ReplaceAll(access, "-", "")
+}
+
+func controllerClass(n Node) string {
+	switch {
+	case n.Missing:
+		return "missing"
+	case n.Custom:
+		return "custom"
+	default:
+		return "controller"
+	}
+}
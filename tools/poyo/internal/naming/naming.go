@@ -0,0 +1,207 @@
+package naming
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"poyo-cli/internal/config"
+)
+
+// URLStyle controls how route paths are written.
+type URLStyle string
+
+const (
+	PascalURL   URLStyle = "pascal"   // /Admin/UserProfile
+	KebabURL    URLStyle = "kebab"    // /admin/user-profile
+	PreserveURL URLStyle = "preserve" // As typed
+)
+
+// FileStyle controls the file name of flat pages (src/pages/Admin/<file>.page.tsx).
+type FileStyle string
+
+const (
+	LowerFile  FileStyle = "lower"  // userprofile.page.tsx
+	KebabFile  FileStyle = "kebab"  // user-profile.page.tsx
+	PascalFile FileStyle = "pascal" // UserProfile.page.tsx
+	CamelFile  FileStyle = "camel"  // userProfile.page.tsx
+)
+
+// Strategy holds the naming rules for route paths and files.
+// Route names, component names and folders are always PascalCase.
+type Strategy struct {
+	URL  URLStyle
+	File FileStyle
+}
+
+// Default matches the conventions of the project template.
+var Default = Strategy{URL: PascalURL, File: LowerFile}
+
+var current = Default
+
+// Load reads the naming section of poyo.config.json and validates it.
+func Load() error {
+	p, err := config.LoadProject()
+	if err != nil {
+		return err
+	}
+	s, err := Parse(p.Naming.URL, p.Naming.File)
+	if err != nil {
+		return fmt.Errorf("poyo.config.json: %w", err)
+	}
+	current = s
+	return nil
+}
+
+// Current returns the strategy loaded from the project configuration.
+func Current() Strategy {
+	return current
+}
+
+// ParseURLStyle validates a URL style name, e.g. from --url-style.
+func ParseURLStyle(url string) (URLStyle, error) {
+	switch URLStyle(url) {
+	case PascalURL, KebabURL, PreserveURL:
+		return URLStyle(url), nil
+	}
+	return "", fmt.Errorf("unknown url naming %q (expected pascal, kebab or preserve)", url)
+}
+
+// Parse builds a strategy from config values. Empty values keep the defaults.
+func Parse(url, file string) (Strategy, error) {
+	s := Default
+	if url != "" {
+		style, err := ParseURLStyle(url)
+		if err != nil {
+			return s, err
+		}
+		s.URL = style
+	}
+	switch FileStyle(file) {
+	case "":
+	case LowerFile, KebabFile, PascalFile, CamelFile:
+		s.File = FileStyle(file)
+	default:
+		return s, fmt.Errorf("unknown file naming %q (expected lower, kebab, pascal or camel)", file)
+	}
+	return s, nil
+}
+
+// Segments splits a path on '/' and '\', dropping empty segments.
+func Segments(path string) []string {
+	return strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' })
+}
+
+// Name returns the route name for the given segments, e.g. "Admin/UserProfile". A route parameter
+// is named after the parameter, without its constraint or default: "{id:int}" gives "Id".
+func (s Strategy) Name(segments []string) string {
+	parts := make([]string, 0, len(segments))
+	for _, seg := range segments {
+		if strings.HasPrefix(seg, "{") {
+			seg, _, _ = strings.Cut(seg, ":")
+			seg, _, _ = strings.Cut(seg, "=")
+		}
+		parts = append(parts, Pascal(seg))
+	}
+	return strings.Join(parts, "/")
+}
+
+// Path returns the route path for the given segments, e.g. "/Admin/UserProfile".
+// Route parameters such as "{id}" are kept as they are.
+func (s Strategy) Path(segments []string) string {
+	parts := make([]string, 0, len(segments))
+	for _, seg := range segments {
+		switch {
+		case strings.HasPrefix(seg, "{"), s.URL == PreserveURL:
+			parts = append(parts, seg)
+		case s.URL == KebabURL:
+			parts = append(parts, Kebab(seg))
+		default:
+			parts = append(parts, Pascal(seg))
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// FileName returns the base name of a flat page file (without ".page.tsx") for a name segment.
+func (s Strategy) FileName(segment string) string {
+	switch s.File {
+	case KebabFile:
+		return Kebab(segment)
+	case PascalFile:
+		return Pascal(segment)
+	case CamelFile:
+		return Camel(segment)
+	default:
+		return strings.ToLower(Pascal(segment))
+	}
+}
+
+// Component returns a valid React component identifier for a route name ("Admin/UserProfile" -> "UserProfile").
+func Component(name string) string {
+	segments := Segments(name)
+	if len(segments) == 0 {
+		return "Page"
+	}
+	c := Pascal(segments[len(segments)-1])
+	if c == "" || unicode.IsDigit(rune(c[0])) {
+		c = "Page" + c
+	}
+	return c
+}
+
+// Words splits an identifier into words on separators and case changes:
+// "user-profile", "user_profile", "userProfile" and "UserProfile" all give ["user"/"User", "profile"/"Profile"].
+// Acronyms stay together: "APIKeys" gives ["API", "Keys"].
+func Words(s string) []string {
+	var words []string
+	for _, chunk := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(chunk)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			prev, cur := runes[i-1], runes[i]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if (unicode.IsLower(prev) || unicode.IsDigit(prev)) && unicode.IsUpper(cur) ||
+				unicode.IsUpper(prev) && unicode.IsUpper(cur) && nextLower {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// Pascal converts a segment to PascalCase: "user-profile" -> "UserProfile", "UserProfile" is kept.
+// An all-uppercase segment such as "USERS" is treated as a word, not an acronym.
+func Pascal(s string) string {
+	if strings.ToUpper(s) == s {
+		s = strings.ToLower(s)
+	}
+	var b strings.Builder
+	for _, w := range Words(s) {
+		r := []rune(w)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+	return b.String()
+}
+
+// Camel converts a segment to camelCase: "UserProfile" -> "userProfile".
+func Camel(s string) string {
+	words := Words(Pascal(s))
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.ToLower(words[0]) + strings.Join(words[1:], "")
+}
+
+// Kebab converts a segment to kebab-case: "UserProfile" -> "user-profile".
+func Kebab(s string) string {
+	words := Words(s)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,44 @@
+package naming
+
+import "testing"
+
+func TestStrategyPath(t *testing.T) {
+	tests := []struct {
+		url  URLStyle
+		path string
+		want string
+	}{
+		{PascalURL, "/admin/user-profile", "/Admin/UserProfile"},
+		{KebabURL, "/Admin/UserProfile", "/admin/user-profile"},
+		{PreserveURL, "/admin/userProfile", "/admin/userProfile"},
+		// Route parameters are never restyled
+		{PascalURL, "/users/{userId}", "/Users/{userId}"},
+		{KebabURL, "/Users/{userId}", "/users/{userId}"},
+		{KebabURL, "/Orders/{id:int}/Edit", "/orders/{id:int}/edit"},
+		{KebabURL, "/Files/{*slug}", "/files/{*slug}"},
+	}
+	for _, tt := range tests {
+		s := Strategy{URL: tt.url, File: LowerFile}
+		if got := s.Path(Segments(tt.path)); got != tt.want {
+			t.Errorf("%s Path(%q) = %q, want %q", tt.url, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestStrategyName(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/admin/user-profile", "Admin/UserProfile"},
+		{"/users/{userId}", "Users/UserId"},
+		{"/orders/{id:int}/edit", "Orders/Id/Edit"},
+		{"/pages/{slug=home}", "Pages/Slug"},
+		{"/files/{*path}", "Files/Path"},
+	}
+	for _, tt := range tests {
+		if got := Default.Name(Segments(tt.path)); got != tt.want {
+			t.Errorf("Name(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Stable error codes reported in JSON mode. Do not rename: wrappers depend on them.
+const (
+	ErrInternal         = "INTERNAL"
+	ErrInvalidArgument  = "INVALID_ARGUMENT"
+	ErrInvalidPath      = "INVALID_PATH"
+	ErrRouteNotFound    = "ROUTE_NOT_FOUND"
+	ErrRouteExists      = "ROUTE_EXISTS"
+	ErrValidationFailed = "VALIDATION_FAILED"
+)
+
+// Error is an error entry in the JSON result.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// CodedError attaches a stable code to an error.
+type CodedError struct {
+	Code string
+	Err  error
+}
+
+func (e *CodedError) Error() string { return e.Err.Error() }
+func (e *CodedError) Unwrap() error { return e.Err }
+
+// Errorf returns an error carrying code.
+func Errorf(code, format string, args ...any) error {
+	return &CodedError{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// CodeOf returns the code attached to err, or ErrInternal.
+func CodeOf(err error) string {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return ErrInternal
+}
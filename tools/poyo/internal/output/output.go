@@ -0,0 +1,152 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"poyo-cli/internal/config"
+)
+
+const (
+	Text = "text"
+	JSON = "json"
+)
+
+// Result is the structured summary a command emits in JSON mode.
+type Result struct {
+	Command  string         `json:"command"`
+	OK       bool           `json:"ok"`
+	Routes   []string       `json:"routes"`
+	Created  []string       `json:"created"`
+	Modified []string       `json:"modified"`
+	Deleted  []string       `json:"deleted"`
+	Warnings []string       `json:"warnings"`
+	Errors   []Error        `json:"errors"`
+	Data     map[string]any `json:"data,omitempty"`
+}
+
+var (
+	format           = Text
+	writer io.Writer = os.Stdout
+	result           = newResult()
+)
+
+func newResult() *Result {
+	return &Result{
+		Routes:   []string{},
+		Created:  []string{},
+		Modified: []string{},
+		Deleted:  []string{},
+		Warnings: []string{},
+		Errors:   []Error{},
+	}
+}
+
+// SetFormat selects "text" (default) or "json" output.
+func SetFormat(f string) error {
+	switch f {
+	case Text, JSON:
+		format = f
+		return nil
+	}
+	return Errorf(ErrInvalidArgument, "unknown output format %q (expected text or json)", f)
+}
+
+// IsJSON reports whether commands should emit a single JSON result instead of text.
+func IsJSON() bool {
+	return format == JSON
+}
+
+// SetCommand records the command name reported in the JSON result.
+func SetCommand(name string) {
+	result.Command = name
+}
+
+// Printf writes free-form text. It is suppressed in JSON mode.
+func Printf(f string, args ...any) {
+	if !IsJSON() {
+		fmt.Fprintf(writer, f, args...)
+	}
+}
+
+// Println writes a line of free-form text. It is suppressed in JSON mode.
+func Println(args ...any) {
+	if !IsJSON() {
+		fmt.Fprintln(writer, args...)
+	}
+}
+
+// Route records a route path touched by the command.
+func Route(path string) {
+	result.Routes = appendUnique(result.Routes, path)
+}
+
+// Created records a created file and prints the text line.
+func Created(file, f string, args ...any) {
+	result.Created = appendUnique(result.Created, rel(file))
+	Printf(f, args...)
+}
+
+// Modified records a modified file and prints the text line.
+func Modified(file, f string, args ...any) {
+	result.Modified = appendUnique(result.Modified, rel(file))
+	Printf(f, args...)
+}
+
+// Deleted records a deleted file and prints the text line.
+func Deleted(file, f string, args ...any) {
+	result.Deleted = appendUnique(result.Deleted, rel(file))
+	Printf(f, args...)
+}
+
+// Warn records a warning for the JSON result. Text mode callers print their own lines.
+func Warn(f string, args ...any) {
+	result.Warnings = append(result.Warnings, fmt.Sprintf(f, args...))
+}
+
+// Data attaches command specific data to the JSON result.
+func Data(key string, value any) {
+	if result.Data == nil {
+		result.Data = make(map[string]any)
+	}
+	result.Data[key] = value
+}
+
+// Flush writes the JSON result, including err if the command failed. It is a no-op in text mode.
+func Flush(err error) {
+	if !IsJSON() {
+		return
+	}
+	result.OK = err == nil
+	if err != nil {
+		result.Errors = append(result.Errors, Error{Code: CodeOf(err), Message: err.Error()})
+	}
+
+	enc := json.NewEncoder(writer)
+	enc.SetIndent("", "  ")
+	enc.Encode(result)
+}
+
+// rel returns file relative to the workspace (the project root outside a workspace), with forward
+// slashes.
+func rel(file string) string {
+	if filepath.IsAbs(file) {
+		if r, err := filepath.Rel(config.WorkspaceDir, file); err == nil && !strings.HasPrefix(r, "..") {
+			file = r
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, e := range list {
+		if e == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
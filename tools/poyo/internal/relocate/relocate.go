@@ -0,0 +1,232 @@
+package relocate
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/gitutil"
+	"poyo-cli/internal/routes"
+	"poyo-cli/internal/scaffold"
+)
+
+type Kind string
+
+const (
+	React Kind = "react"
+	View  Kind = "view"
+)
+
+// minScore is the lowest score at which a missing/untracked pair is offered as a move.
+const minScore = 0.5
+
+// Missing is a route whose file of the given kind no longer exists on disk.
+type Missing struct {
+	Route routes.Route
+	Kind  Kind
+}
+
+// Move pairs a route's missing file with an untracked file that most likely replaced it.
+type Move struct {
+	Route routes.Route
+	Kind  Kind
+	From  string // Old path, as stored in routes.json
+	To    string // Untracked path, relative to the client or server dir
+	Score float64
+}
+
+var (
+	exportDefaultRe = regexp.MustCompile(`export\s+default\s+(?:function\s+|class\s+)?([A-Z]\w*)`)
+	componentDeclRe = regexp.MustCompile(`(?m)^(?:export\s+)?(?:const|function|class)\s+([A-Z]\w*)`)
+	pageNameRe      = regexp.MustCompile(`data-page-name\s*=\s*"([^"]*)"`)
+)
+
+// Detect pairs missing route files with untracked files of the same kind.
+// Each missing file and each untracked file is used at most once, best scores first.
+func Detect(missing []Missing, untrackedReact, untrackedViews []string) []Move {
+	var candidates []Move
+
+	for _, m := range missing {
+		from, baseDir, untracked := m.Route.Files.React, config.ClientDir, untrackedReact
+		if m.Kind == View {
+			from, baseDir, untracked = m.Route.Files.View, config.ServerDir, untrackedViews
+		}
+
+		// Without history the reference is the page template, which every page made from it
+		// resembles: then only a file naming the route can replace it
+		reference := previousContent(baseDir, from)
+		fromTemplate := reference == ""
+		if fromTemplate {
+			reference = templateContent(m.Route, m.Kind)
+		}
+
+		for _, u := range untracked {
+			data, err := os.ReadFile(filepath.Join(baseDir, u))
+			if err != nil {
+				continue
+			}
+			content := string(data)
+
+			var nameScore float64
+			if m.Kind == React {
+				nameScore = componentScore(m.Route.Name, reference, content)
+			} else {
+				nameScore = pageNameScore(m.Route.Name, content)
+			}
+			if fromTemplate && nameScore == 0 {
+				continue
+			}
+			score := 0.6*Similarity(reference, content) + 0.4*nameScore
+
+			if score >= minScore {
+				candidates = append(candidates, Move{Route: m.Route, Kind: m.Kind, From: from, To: u, Score: score})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	usedFrom := make(map[string]bool)
+	usedTo := make(map[string]bool)
+	var moves []Move
+	for _, c := range candidates {
+		fromKey := string(c.Kind) + ":" + c.Route.Path
+		toKey := string(c.Kind) + ":" + c.To
+		if usedFrom[fromKey] || usedTo[toKey] {
+			continue
+		}
+		usedFrom[fromKey] = true
+		usedTo[toKey] = true
+		moves = append(moves, c)
+	}
+	return moves
+}
+
+// Apply rewrites the file paths of the moved routes in r. Everything else on the route is kept.
+func Apply(r []routes.Route, moves []Move) {
+	for _, mv := range moves {
+		for i := range r {
+			if r[i].Path != mv.Route.Path {
+				continue
+			}
+			if mv.Kind == React {
+				r[i].Files.React = mv.To
+			} else {
+				r[i].Files.View = mv.To
+			}
+		}
+	}
+}
+
+// previousContent returns the last committed content of a deleted file, if git knows it.
+func previousContent(baseDir, file string) string {
+	if !gitutil.Available(config.RootDir) {
+		return ""
+	}
+	rel, err := filepath.Rel(config.RootDir, filepath.Join(baseDir, file))
+	if err != nil {
+		return ""
+	}
+	data, err := gitutil.Show(config.RootDir, "HEAD", rel)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func templateContent(rt routes.Route, kind Kind) string {
+	ctx := scaffold.NewContext(rt)
+	content, err := scaffold.MVCView(ctx)
+	if kind == React {
+		content, err = scaffold.ReactPage(ctx, scaffold.BlankVariant)
+	}
+	if err != nil {
+		return ""
+	}
+	return content
+}
+
+// componentScore compares the component name declared in content with the route name
+// and with the component declared in the old file.
+func componentScore(routeName, reference, content string) float64 {
+	got := ComponentName(content)
+	if got == "" {
+		return 0
+	}
+	leaf := routeName[strings.LastIndex(routeName, "/")+1:]
+	if old := ComponentName(reference); old != "" && old == got {
+		return 1
+	}
+	if strings.EqualFold(got, leaf) || strings.EqualFold(got, leaf+"Page") {
+		return 1
+	}
+	if strings.Contains(strings.ToLower(got), strings.ToLower(leaf)) {
+		return 0.5
+	}
+	return 0
+}
+
+func pageNameScore(routeName, content string) float64 {
+	m := pageNameRe.FindStringSubmatch(content)
+	if m == nil {
+		return 0
+	}
+	if m[1] == routeName {
+		return 1
+	}
+	if strings.EqualFold(m[1], routeName) {
+		return 0.8
+	}
+	return 0
+}
+
+// ComponentName returns the default exported React component declared in a page source.
+func ComponentName(content string) string {
+	if m := exportDefaultRe.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	if m := componentDeclRe.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// Similarity returns the Dice coefficient of the trimmed, non-empty lines of a and b (0..1).
+func Similarity(a, b string) float64 {
+	la, lb := lines(a), lines(b)
+	if len(la) == 0 && len(lb) == 0 {
+		return 1
+	}
+	if len(la) == 0 || len(lb) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int)
+	for _, l := range la {
+		counts[l]++
+	}
+	common := 0
+	for _, l := range lb {
+		if counts[l] > 0 {
+			counts[l]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(la)+len(lb))
+}
+
+func lines(s string) []string {
+	var out []string
+	for _, l := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		l = strings.TrimSpace(l)
+		if l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
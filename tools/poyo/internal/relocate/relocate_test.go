@@ -0,0 +1,56 @@
+package relocate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/routes"
+	"poyo-cli/internal/scaffold"
+)
+
+func TestDetectFromTemplate(t *testing.T) {
+	root := t.TempDir()
+	config.RootDir, config.ClientDir, config.TemplatesDir = root, root, filepath.Join(root, ".poyo", "templates")
+
+	// A project page template whose boilerplate outweighs the lines naming the page
+	tmpl := "import type React from \"react\";\nimport { Layout } from \"~/components/layout\";\nimport { Card } from \"~/components/card\";\nimport { Toolbar } from \"~/components/toolbar\";\nimport { Footer } from \"~/components/footer\";\nimport { Breadcrumbs } from \"~/components/breadcrumbs\";\nimport { Sidebar } from \"~/components/sidebar\";\n\n" +
+		"const {{.Component}}: React.FC = () => {\n\treturn (\n\t\t<Layout>\n\t\t\t<Sidebar />\n\t\t\t<Breadcrumbs />\n\t\t\t<Toolbar />\n\t\t\t<Card>\n\t\t\t\t<h1>{{.Name}}</h1>\n\t\t\t</Card>\n\t\t\t<Footer />\n\t\t</Layout>\n\t);\n};\n\nexport default {{.Component}};\n"
+	if err := os.MkdirAll(config.TemplatesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(config.TemplatesDir, scaffold.PageTmpl), []byte(tmpl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pages generated from it for other routes, without git history to compare with
+	page := func(file, name string) {
+		content, err := scaffold.ReactPage(scaffold.NewContext(routes.Route{Path: "/" + name, Name: name}), scaffold.BlankVariant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	page("src/pages/Invoices/index.page.tsx", "Invoices")
+	page("src/pages/Sales/Orders/index.page.tsx", "Orders")
+
+	orders := routes.Route{Path: "/Orders", Name: "Orders", Files: routes.Files{React: "src/pages/Orders/index.page.tsx"}}
+	customers := routes.Route{Path: "/Customers", Name: "Customers", Files: routes.Files{React: "src/pages/Customers/index.page.tsx"}}
+	missing := []Missing{{Route: orders, Kind: React}, {Route: customers, Kind: React}}
+	untracked := []string{"src/pages/Invoices/index.page.tsx", "src/pages/Sales/Orders/index.page.tsx"}
+
+	moves := Detect(missing, untracked, nil)
+	if len(moves) != 1 {
+		t.Fatalf("Detect() = %+v, want only the Orders move", moves)
+	}
+	if mv := moves[0]; mv.Route.Path != "/Orders" || mv.To != "src/pages/Sales/Orders/index.page.tsx" {
+		t.Errorf("Detect() = %s -> %s, want /Orders -> src/pages/Sales/Orders/index.page.tsx", mv.Route.Path, mv.To)
+	}
+}
@@ -0,0 +1,160 @@
+package routes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type ChangeKind string
+
+const (
+	Added    ChangeKind = "added"
+	Removed  ChangeKind = "removed"
+	Modified ChangeKind = "changed"
+)
+
+// Change is a single semantic difference between two versions of routes.json.
+type Change struct {
+	Kind  ChangeKind `json:"kind"`
+	Path  string     `json:"path"`
+	Field string     `json:"field,omitempty"`
+	From  string     `json:"from,omitempty"`
+	To    string     `json:"to,omitempty"`
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("added %s (%s)", c.Path, c.To)
+	case Removed:
+		return fmt.Sprintf("removed %s (%s)", c.Path, c.From)
+	}
+
+	switch c.Field {
+	case "access":
+		return fmt.Sprintf("%s became %s (was %s)", c.Path, c.To, c.From)
+	case "path":
+		return fmt.Sprintf("%s moved to %s", c.From, c.To)
+	}
+	if c.From == "" {
+		return fmt.Sprintf("%s of %s set to %q", c.Field, c.Path, c.To)
+	}
+	if c.To == "" {
+		return fmt.Sprintf("%s of %s removed (was %q)", c.Field, c.Path, c.From)
+	}
+	return fmt.Sprintf("%s of %s changed from %q to %q", c.Field, c.Path, c.From, c.To)
+}
+
+// Access returns the access level of a route: "protected", "public" or "guest-only".
+func Access(r Route) string {
+	switch {
+	case r.IsGuestOnly:
+		return "guest-only"
+	case r.IsPublic:
+		return "public"
+	default:
+		return "protected"
+	}
+}
+
+// Diff compares two route lists by path and returns the changes from old to new, sorted by path.
+// A route whose old path is listed in its redirects, or differs only in case, is reported as moved,
+// not as removed and added.
+func Diff(old, new []Route) []Change {
+	oldByPath := make(map[string]Route)
+	for _, r := range old {
+		oldByPath[r.Path] = r
+	}
+	newByPath := make(map[string]Route)
+	for _, r := range new {
+		newByPath[r.Path] = r
+	}
+
+	var changes []Change
+	movedPaths := make(map[string]bool)
+	for _, r := range new {
+		prev, ok := oldByPath[r.Path]
+		if !ok {
+			if from, moved := movedFrom(r, oldByPath, newByPath, movedPaths); moved {
+				prev = oldByPath[from]
+				changes = append(changes, Change{Kind: Modified, Path: r.Path, Field: "path", From: from, To: r.Path})
+				changes = append(changes, fieldChanges(prev, r)...)
+				movedPaths[from] = true
+				continue
+			}
+			changes = append(changes, Change{Kind: Added, Path: r.Path, To: Access(r)})
+			continue
+		}
+		changes = append(changes, fieldChanges(prev, r)...)
+	}
+	for _, r := range old {
+		if _, ok := newByPath[r.Path]; !ok && !movedPaths[r.Path] {
+			changes = append(changes, Change{Kind: Removed, Path: r.Path, From: Access(r)})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// movedFrom returns the old path of r if it is one of its redirects, or differs only in case,
+// and is no longer a route. An old path in used was already claimed by another route, which keeps it.
+func movedFrom(r Route, oldByPath, newByPath map[string]Route, used map[string]bool) (string, bool) {
+	// Sorted, so that the same old path is picked on every run
+	froms := make([]string, 0, len(oldByPath))
+	for from := range oldByPath {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+	for _, from := range froms {
+		if _, stillThere := newByPath[from]; !stillThere && !used[from] && strings.EqualFold(from, r.Path) {
+			return from, true
+		}
+	}
+	for _, from := range r.Redirects {
+		if _, stillThere := newByPath[from]; stillThere || used[from] {
+			continue
+		}
+		if _, ok := oldByPath[from]; ok {
+			return from, true
+		}
+	}
+	return "", false
+}
+
+func fieldChanges(a, b Route) []Change {
+	var changes []Change
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, Change{Kind: Modified, Path: b.Path, Field: field, From: from, To: to})
+		}
+	}
+
+	add("access", Access(a), Access(b))
+	add("name", a.Name, b.Name)
+	add("react page", a.Files.React, b.Files.React)
+	add("view", a.Files.View, b.Files.View)
+	add("controller", a.Controller, b.Controller)
+	add("action", a.Action, b.Action)
+	add("redirects", strings.Join(a.Redirects, ", "), strings.Join(b.Redirects, ", "))
+
+	keys := make(map[string]bool)
+	for k := range a.SEO {
+		keys[k] = true
+	}
+	for k := range b.SEO {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	for _, k := range sorted {
+		add("SEO "+k, a.SEO[k], b.SEO[k])
+	}
+	return changes
+}
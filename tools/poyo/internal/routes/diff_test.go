@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffMoved(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new []Route
+		want     []Change
+	}{
+		{
+			name: "redirect",
+			old:  []Route{{Path: "/UserSettings", Name: "UserSettings"}},
+			new:  []Route{{Path: "/user-settings", Name: "UserSettings", Redirects: []string{"/UserSettings"}}},
+			want: []Change{
+				{Kind: Modified, Path: "/user-settings", Field: "path", From: "/UserSettings", To: "/user-settings"},
+				{Kind: Modified, Path: "/user-settings", Field: "redirects", To: "/UserSettings"},
+			},
+		},
+		{
+			name: "case only",
+			old:  []Route{{Path: "/Users/{id}", Name: "Users"}},
+			new:  []Route{{Path: "/users/{id}", Name: "Users"}},
+			want: []Change{{Kind: Modified, Path: "/users/{id}", Field: "path", From: "/Users/{id}", To: "/users/{id}"}},
+		},
+		{
+			// Both old paths match; the first in sorted order is picked, the other is removed
+			name: "several candidates",
+			old:  []Route{{Path: "/about", Name: "About"}, {Path: "/About", Name: "About"}},
+			new:  []Route{{Path: "/ABOUT", Name: "About"}},
+			want: []Change{
+				{Kind: Modified, Path: "/ABOUT", Field: "path", From: "/About", To: "/ABOUT"},
+				{Kind: Removed, Path: "/about", From: "protected"},
+			},
+		},
+		{
+			// Only the first route claims /Old; the second is a new route
+			name: "old path claimed twice",
+			old:  []Route{{Path: "/Old", Name: "Old"}},
+			new: []Route{
+				{Path: "/a", Name: "Old", Redirects: []string{"/Old"}},
+				{Path: "/b", Name: "Old", Redirects: []string{"/Old"}},
+			},
+			want: []Change{
+				{Kind: Modified, Path: "/a", Field: "path", From: "/Old", To: "/a"},
+				{Kind: Modified, Path: "/a", Field: "redirects", To: "/Old"},
+				{Kind: Added, Path: "/b", To: "protected"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 20 { // Map order varies between runs
+				if got := Diff(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("Diff() = %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"os"
 	"sort"
-	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/naming"
 )
 
 type Files struct {
@@ -21,6 +24,7 @@ type Route struct {
 	Controller  string            `json:"controller,omitempty"`
 	Action      string            `json:"action,omitempty"`
 	SEO         map[string]string `json:"seo,omitempty"`
+	Redirects   []string          `json:"redirects,omitempty"` // Previous paths, permanently redirected to Path
 }
 
 func Read(path string) ([]Route, error) {
@@ -33,6 +37,11 @@ func Read(path string) ([]Route, error) {
 		return nil, err
 	}
 
+	return Parse(data)
+}
+
+// Parse decodes the contents of a routes.json file.
+func Parse(data []byte) ([]Route, error) {
 	var routes []Route
 	if err := json.Unmarshal(data, &routes); err != nil {
 		return nil, err
@@ -45,12 +54,12 @@ func Write(path string, routes []Route) error {
 		return routes[i].Path < routes[j].Path
 	})
 
-	data, err := json.MarshalIndent(routes, "", "  ")
+	data, err := json.MarshalIndent(routes, "", "\t")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, append(data, '\n'), 0644)
+	return editorconfig.RewriteFile(path, string(data)+"\n")
 }
 
 func ResolvePaths(name string, isFlat bool) Files {
@@ -91,14 +100,14 @@ func ResolvePaths(name string, isFlat bool) Files {
 		}
 
 		return Files{
-			React: "src/pages/" + basePath + strings.ToLower(leaf) + ".page.tsx",
-			View:  "Views/" + basePath + leaf + ".cshtml",
+			React: config.PagesPath() + "/" + basePath + naming.Current().FileName(leaf) + ".page.tsx",
+			View:  config.ViewsPath() + "/" + basePath + leaf + ".cshtml",
 		}
 	}
 	
 	// Default folder structure
 	return Files{
-		React: "src/pages/" + name + "/index.page.tsx",
-		View:  "Views/" + name + "/Index.cshtml",
+		React: config.PagesPath() + "/" + name + "/index.page.tsx",
+		View:  config.ViewsPath() + "/" + name + "/Index.cshtml",
 	}
 }
@@ -0,0 +1,136 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/csharp"
+	"poyo-cli/internal/dotnet"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/routes"
+)
+
+// authAttributes returns the attributes giving an action the access level of a route, matching
+// PageController's Index, PublicIndex and GuestIndex.
+func authAttributes(rt routes.Route) []string {
+	switch routes.Access(rt) {
+	case "guest-only":
+		return []string{"AllowAnonymous", dotnet.ServerNamespace(config.ServerDir) + ".Middleware.Auth.GuestOnly"}
+	case "public":
+		return []string{"AllowAnonymous"}
+	}
+	return []string{"Authorize"}
+}
+
+// actionAccess returns the access level declared by an action's attributes, empty if it has none.
+func actionAccess(m csharp.Member) string {
+	switch {
+	case m.HasAttribute("GuestOnly"):
+		return "guest-only"
+	case m.HasAttribute("AllowAnonymous"):
+		return "public"
+	case m.HasAttribute("Authorize"):
+		return "protected"
+	}
+	return ""
+}
+
+func isAuthAttribute(a csharp.Attribute) bool {
+	short := strings.TrimSuffix(a.Name[strings.LastIndex(a.Name, ".")+1:], "Attribute")
+	return short == "Authorize" || short == "AllowAnonymous" || short == "GuestOnly"
+}
+
+// SyncAccess rewrites the [Authorize]/[AllowAnonymous]/[GuestOnly] attributes of a route's controller
+// action to match its access level in routes.json. Attributes that already match are kept as they are
+// (e.g. [Authorize(Roles = "Admin")]). It returns the controller file and whether it changed.
+func SyncAccess(rt routes.Route) (string, bool, error) {
+	file := controllers.Path(config.ControllersDir, rt.Controller)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return file, false, err
+	}
+	content := string(data)
+
+	f := csharp.Parse(content)
+	class := f.Type(controllers.ClassName(rt.Controller))
+	if class == nil {
+		return file, false, fmt.Errorf("class %s not found in %s", controllers.ClassName(rt.Controller), fsutil.RelToRoot(file))
+	}
+	m, ok := class.Method(rt.Action)
+	if !ok {
+		return file, false, fmt.Errorf("action %s not found in %s", rt.Action, fsutil.RelToRoot(file))
+	}
+	if actionAccess(m) == routes.Access(rt) {
+		return file, false, nil
+	}
+
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+
+	// Drop the auth attributes, keeping the others of the same list
+	var lists []int
+	byList := make(map[int][]csharp.Attribute)
+	for _, a := range m.Attributes {
+		if _, seen := byList[a.ListStart]; !seen {
+			lists = append(lists, a.ListStart)
+		}
+		byList[a.ListStart] = append(byList[a.ListStart], a)
+	}
+	for _, start := range lists {
+		attrs := byList[start]
+		var kept []string
+		for _, a := range attrs {
+			if !isAuthAttribute(a) {
+				kept = append(kept, content[a.Start:a.End])
+			}
+		}
+		end := attrs[0].ListEnd
+		switch {
+		case len(kept) == len(attrs):
+		case len(kept) > 0:
+			edits = append(edits, edit{start, end, "[" + strings.Join(kept, ", ") + "]"})
+		default:
+			lineStart := csharp.LineStart(content, start)
+			rest := strings.TrimLeft(content[end:], " \t\r")
+			if strings.TrimSpace(content[lineStart:start]) == "" && strings.HasPrefix(rest, "\n") {
+				// The list is alone on its line
+				edits = append(edits, edit{lineStart, len(content) - len(rest) + 1, ""})
+			} else {
+				edits = append(edits, edit{start, len(content) - len(strings.TrimLeft(content[end:], " \t")), ""})
+			}
+		}
+	}
+
+	// Add the attributes of the route's access level right above the declaration
+	indent := csharp.Indent(content, m.Decl)
+	var lines strings.Builder
+	for _, a := range authAttributes(rt) {
+		lines.WriteString(indent + "[" + a + "]" + newline)
+	}
+	edits = append(edits, edit{csharp.LineStart(content, m.Decl), csharp.LineStart(content, m.Decl), lines.String()})
+
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+	for _, e := range edits {
+		content = content[:e.start] + e.text + content[e.end:]
+	}
+	content = ensureUsing(content, "Microsoft.AspNetCore.Authorization")
+
+	if err := editorconfig.UpdateFile(file, content); err != nil {
+		return file, false, err
+	}
+	return file, true, nil
+}
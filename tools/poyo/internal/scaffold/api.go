@@ -0,0 +1,189 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/dotnet"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+)
+
+const (
+	APIControllerTmpl       = "api-controller.cs.tmpl"
+	APIServiceInterfaceTmpl = "api-service-interface.cs.tmpl"
+	APIServiceTmpl          = "api-service.cs.tmpl"
+	APIRequestTmpl          = "api-request.cs.tmpl"
+	APIResponseTmpl         = "api-response.cs.tmpl"
+)
+
+// APIContext is the data the api-*.cs.tmpl templates are executed with.
+type APIContext struct {
+	Name           string // Domain, e.g. "Products"
+	Namespace      string // Root namespace of the server project (.csproj RootNamespace), e.g. "Poyo.Server"
+	Service        string // Service interface, e.g. "IProductsService"
+	Implementation string // Service class, e.g. "ProductsService"
+	Field          string // Constructor parameter, e.g. "productsService"
+	Public         bool   // [AllowAnonymous] instead of [Authorize] on the controller
+	Actions        []APIAction
+	Action         APIAction // The action a request/response model is generated for
+}
+
+// APIAction is an endpoint of a generated API controller.
+type APIAction struct {
+	Name     string // e.g. "Create"
+	Verb     string // HTTP method attribute suffix: Get, Post, Put or Delete
+	Binding  string // FromQuery or FromBody
+	Request  string // e.g. "CreateProductsRequest"
+	Response string // e.g. "CreateProductsResponse"
+	// Result returned when the service gives null: NotFound for reads, BadRequest otherwise
+	FailResult  string // e.g. "NotFound"
+	FailStatus  string // e.g. "Status404NotFound"
+	FailMessage string // e.g. "Products not found."
+}
+
+// NewAPIContext builds the context for an API named name with the given action names.
+func NewAPIContext(name string, actions []string, public bool) APIContext {
+	name = naming.Pascal(name)
+	ctx := APIContext{
+		Name:           name,
+		Namespace:      dotnet.ServerNamespace(config.ServerDir),
+		Service:        "I" + name + "Service",
+		Implementation: name + "Service",
+		Field:          naming.Camel(name) + "Service",
+		Public:         public,
+	}
+	for _, a := range actions {
+		a = naming.Pascal(a)
+		verb := httpVerb(a)
+		action := APIAction{
+			Name:        a,
+			Verb:        verb,
+			Binding:     "FromBody",
+			Request:     a + name + "Request",
+			Response:    a + name + "Response",
+			FailResult:  "BadRequest",
+			FailStatus:  "Status400BadRequest",
+			FailMessage: fmt.Sprintf("%s %s failed.", a, name),
+		}
+		if verb == "Get" || verb == "Delete" {
+			action.Binding = "FromQuery"
+		}
+		if verb == "Get" {
+			action.FailResult, action.FailStatus, action.FailMessage = "NotFound", "Status404NotFound", name+" not found."
+		}
+		ctx.Actions = append(ctx.Actions, action)
+	}
+	return ctx
+}
+
+// httpVerb infers the HTTP method from an action name: Get/List/Search are GET, Update is PUT,
+// Delete/Remove are DELETE, anything else (Create, custom commands) is POST.
+func httpVerb(action string) string {
+	first := naming.Words(action)[0]
+	switch first {
+	case "Get", "List", "Find", "Search", "Fetch":
+		return "Get"
+	case "Update", "Edit", "Set":
+		return "Put"
+	case "Delete", "Remove":
+		return "Delete"
+	}
+	return "Post"
+}
+
+// APIFiles returns the files of an API relative to the server project, keyed by template.
+func APIFiles(ctx APIContext) map[string]string {
+	return map[string]string{
+		APIControllerTmpl:       filepath.Join("Controllers", "Api", ctx.Name+"Controller.cs"),
+		APIServiceInterfaceTmpl: filepath.Join("Services", ctx.Name, ctx.Service+".cs"),
+		APIServiceTmpl:          filepath.Join("Services", ctx.Name, ctx.Implementation+".cs"),
+	}
+}
+
+// ScaffoldAPI writes the API controller, its request/response models and the service.
+// Existing files are kept.
+func ScaffoldAPI(ctx APIContext) error {
+	type file struct {
+		tmpl, rel, label string
+		ctx              APIContext
+	}
+	files := []file{
+		{APIControllerTmpl, APIFiles(ctx)[APIControllerTmpl], "API Controller", ctx},
+	}
+	for _, a := range ctx.Actions {
+		actionCtx := ctx
+		actionCtx.Action = a
+		files = append(files,
+			file{APIRequestTmpl, filepath.Join("Models", ctx.Name, "Requests", a.Request+".cs"), "Request", actionCtx},
+			file{APIResponseTmpl, filepath.Join("Models", ctx.Name, "Responses", a.Response+".cs"), "Response", actionCtx},
+		)
+	}
+	files = append(files,
+		file{APIServiceInterfaceTmpl, APIFiles(ctx)[APIServiceInterfaceTmpl], "Service Interface", ctx},
+		file{APIServiceTmpl, APIFiles(ctx)[APIServiceTmpl], "Service", ctx},
+	)
+
+	for _, f := range files {
+		full := filepath.Join(config.ServerDir, f.rel)
+		rel := filepath.ToSlash(f.rel)
+		if _, err := os.Stat(full); err == nil {
+			output.Printf("[EXISTS] %s: %s\n", f.label, rel)
+			continue
+		}
+		content, err := Render(f.tmpl, f.ctx)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return err
+		}
+		if err := editorconfig.WriteFile(full, content); err != nil {
+			return err
+		}
+		output.Created(full, "[CREATED] %s: %s\n", f.label, rel)
+	}
+	return nil
+}
+
+var addScopedRe = regexp.MustCompile(`(?m)^builder\.Services\.Add(?:Scoped|Transient|Singleton)<[^\n]*\);[ \t]*\r?\n`)
+
+// RegisterService adds "builder.Services.AddScoped<IService, Service>();" to Program.cs after the
+// existing service registrations. It reports false if the service is already registered.
+func RegisterService(ctx APIContext) (bool, error) {
+	program := filepath.Join(config.ServerDir, "Program.cs")
+	data, err := os.ReadFile(program)
+	if err != nil {
+		return false, err
+	}
+	content := string(data)
+
+	ns := ctx.Namespace + ".Services." + ctx.Name
+	if regexp.MustCompile(`Add\w+<\s*(?:` + regexp.QuoteMeta(ns) + `\.)?` + regexp.QuoteMeta(ctx.Service) + `\s*,`).MatchString(content) {
+		return false, nil
+	}
+	line := fmt.Sprintf("builder.Services.AddScoped<%s.%s, %s.%s>();\n", ns, ctx.Service, ns, ctx.Implementation)
+
+	var at int
+	if all := addScopedRe.FindAllStringIndex(content, -1); len(all) > 0 {
+		at = all[len(all)-1][1]
+	} else if i := strings.Index(content, "var app = builder.Build();"); i >= 0 {
+		line = "// Add Application Services\n" + line + "\n"
+		at = i
+	} else {
+		return false, fmt.Errorf("no place to register %s found in %s", ctx.Service, fsutil.RelToRoot(program))
+	}
+
+	content = content[:at] + line + content[at:]
+	if err := editorconfig.UpdateFile(program, content); err != nil {
+		return false, err
+	}
+	output.Modified(program, "[UPDATED] Program.cs: registered %s\n", ctx.Service)
+	return true, nil
+}
@@ -0,0 +1,105 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/output"
+)
+
+var exportFromRe = regexp.MustCompile(`^export\s[\s\S]*\sfrom\s+["']([^"']+)["']\s*;?\s*$`)
+
+// AddExport adds an export statement such as `export * from "./auth";` to an index.ts barrel,
+// creating it if needed. The "export ... from" statements are kept sorted by module, and nothing is
+// added if the module is already exported. A placeholder barrel (only comments and "export {};")
+// is replaced. It reports whether the barrel changed.
+func AddExport(barrel, stmt string) (bool, error) {
+	data, err := os.ReadFile(barrel)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	// Split into statements: comments and other code before the first export are kept as a header
+	var header, exports []string
+	var current []string
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if len(current) == 0 && (trimmed == "" || trimmed == "export {};") {
+			continue
+		}
+		if len(current) == 0 && !strings.HasPrefix(trimmed, "export") {
+			if len(exports) == 0 {
+				header = append(header, line)
+			} else {
+				exports = append(exports, line)
+			}
+			continue
+		}
+		current = append(current, line)
+		if strings.HasSuffix(trimmed, ";") || exportFromRe.MatchString(strings.Join(current, "\n")) {
+			exports = append(exports, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		exports = append(exports, strings.Join(current, "\n"))
+	}
+
+	module := exportModule(stmt)
+	for _, e := range exports {
+		if exportModule(e) == module {
+			return false, nil
+		}
+	}
+	if len(exports) == 0 {
+		// Placeholder barrel: its comments describe the empty state
+		header = nil
+	}
+	exports = append(exports, stmt)
+	sort.SliceStable(exports, func(i, j int) bool {
+		return exportModule(exports[i]) < exportModule(exports[j])
+	})
+
+	var b strings.Builder
+	for _, line := range header {
+		b.WriteString(line + "\n")
+	}
+	if len(header) > 0 {
+		b.WriteString("\n")
+	}
+	for _, e := range exports {
+		b.WriteString(e + "\n")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(barrel), 0755); err != nil {
+		return false, err
+	}
+	if err := editorconfig.UpdateFile(barrel, b.String()); err != nil {
+		return false, err
+	}
+	rel, err := filepath.Rel(config.ClientDir, barrel)
+	if err != nil {
+		rel = fsutil.RelToRoot(barrel)
+	}
+	if data == nil {
+		output.Created(barrel, "[CREATED] Barrel: %s\n", filepath.ToSlash(rel))
+	} else {
+		output.Modified(barrel, "[UPDATED] Barrel: %s\n", filepath.ToSlash(rel))
+	}
+	return true, nil
+}
+
+// exportModule returns the module an export statement re-exports from, e.g. "./auth".
+// Other statements sort last.
+func exportModule(stmt string) string {
+	if m := exportFromRe.FindStringSubmatch(strings.TrimSpace(stmt)); m != nil {
+		return m[1]
+	}
+	return "\uffff"
+}
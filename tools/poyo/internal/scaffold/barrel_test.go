@@ -0,0 +1,63 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddExport(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string // Empty: no barrel yet
+		stmt     string
+		want     string
+		changed  bool
+	}{
+		{
+			name:     "sorted, line endings kept",
+			existing: "// Hooks\r\n\r\nexport { useAuth } from \"./use-auth\";\r\nexport { useUser } from \"./use-user\";\r\n",
+			stmt:     "export { usePage } from \"./use-page\";",
+			want:     "// Hooks\r\n\r\nexport { useAuth } from \"./use-auth\";\r\nexport { usePage } from \"./use-page\";\r\nexport { useUser } from \"./use-user\";\r\n",
+			changed:  true,
+		},
+		{
+			name:     "already exported",
+			existing: "export * from \"./auth\";\n",
+			stmt:     "export * from './auth';",
+			want:     "export * from \"./auth\";\n",
+		},
+		{
+			name:     "placeholder replaced",
+			existing: "// Nothing here yet\nexport {};\n",
+			stmt:     "export * from \"./auth\";",
+			want:     "export * from \"./auth\";\n",
+			changed:  true,
+		},
+		{
+			name:     "multi-line statement",
+			existing: "export {\n\ta,\n\tb,\n} from \"./z\";\n",
+			stmt:     "export * from \"./a\";",
+			want:     "export * from \"./a\";\nexport {\n\ta,\n\tb,\n} from \"./z\";\n",
+			changed:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			barrel := filepath.Join(t.TempDir(), "index.ts")
+			if tt.existing != "" {
+				if err := os.WriteFile(barrel, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			changed, err := AddExport(barrel, tt.stmt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := os.ReadFile(barrel)
+			if changed != tt.changed || string(got) != tt.want {
+				t.Errorf("AddExport() = %v, %q; want %v, %q", changed, got, tt.changed, tt.want)
+			}
+		})
+	}
+}
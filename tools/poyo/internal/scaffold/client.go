@@ -0,0 +1,313 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+)
+
+const (
+	ComponentTmpl   = "component.tsx.tmpl"
+	HookTmpl        = "hook.ts.tmpl"
+	ServiceTmpl     = "service.ts.tmpl"
+	APIQueryTmpl    = "api-query.ts.tmpl"
+	APIMutationTmpl = "api-mutation.ts.tmpl"
+)
+
+// ClientContext is the data the client building block templates are executed with.
+type ClientContext struct {
+	Name     string // Component, hook or API domain, e.g. "TextField", "Products"
+	File     string // Generated file relative to the client project, e.g. "src/components/forms/text-field.tsx"
+	Hook     string // Hook name, e.g. "useCreateProducts"
+	Service  string // Service object, e.g. "productsService"
+	Endpoint string // ENDPOINTS key of the domain, e.g. "PRODUCTS"
+	QueryKey string // First query key segment of an API query hook, e.g. "products"
+	Actions  []ClientAction
+	Action   ClientAction // The action an API hook calls
+}
+
+// ClientAction is a method of a generated service, calling an action of "poyo make api".
+type ClientAction struct {
+	Name     string // e.g. "Create"
+	Method   string // Service method, e.g. "create"
+	Verb     string // httpClient method: get, post, put or delete
+	Body     bool   // The request is sent as the body (post/put), otherwise as query params
+	Endpoint string // ENDPOINTS key of the action, e.g. "CREATE"
+	Path     string // e.g. "/api/Products/Create"
+	Request  string // DTO schema, e.g. "CreateProductsRequest"
+	Response string // DTO schema, e.g. "CreateProductsResponse"
+}
+
+// clientSrc returns a path below the client's src folder.
+func clientSrc(elem ...string) string {
+	return filepath.Join(append([]string{config.ClientDir, "src"}, elem...)...)
+}
+
+// constantCase converts a name to an ENDPOINTS key: "UserProfile" -> "USER_PROFILE".
+func constantCase(s string) string {
+	return strings.ToUpper(strings.ReplaceAll(naming.Kebab(s), "-", "_"))
+}
+
+// NewComponentContext builds the context of a component. The name may start with folders below
+// src/components: "forms/TextField" -> src/components/forms/text-field.tsx.
+func NewComponentContext(name string) (ClientContext, error) {
+	segments := naming.Segments(name)
+	if len(segments) == 0 {
+		return ClientContext{}, fmt.Errorf("invalid component name %q", name)
+	}
+	dirs := make([]string, 0, len(segments)-1)
+	for _, s := range segments[:len(segments)-1] {
+		if s == "." || s == ".." {
+			return ClientContext{}, fmt.Errorf("invalid component folder %q", name)
+		}
+		dirs = append(dirs, naming.Kebab(s))
+	}
+	component := naming.Pascal(segments[len(segments)-1])
+	if component == "" {
+		return ClientContext{}, fmt.Errorf("invalid component name %q", name)
+	}
+	file := path.Join(append(append([]string{"src", "components"}, dirs...), naming.Kebab(component)+".tsx")...)
+	return ClientContext{Name: component, File: file}, nil
+}
+
+// NewHookContext builds the context of a hook: "page", "usePage" and "use-page" all give usePage
+// in src/hooks/use-page.ts.
+func NewHookContext(name string) (ClientContext, error) {
+	// "use" is only a prefix when it is a word of its own: "user" is not "useR"
+	words := naming.Words(name)
+	if len(words) > 1 && strings.EqualFold(words[0], "use") {
+		words = words[1:]
+	}
+	pascal := naming.Pascal(strings.Join(words, "-"))
+	if pascal == "" {
+		return ClientContext{}, fmt.Errorf("invalid hook name %q", name)
+	}
+	file := "src/hooks/use-" + naming.Kebab(pascal) + ".ts"
+	return ClientContext{Name: pascal, Hook: "use" + pascal, File: file}, nil
+}
+
+// NewServiceContext builds the context of the service of an API domain, with one method per action.
+// The DTO names follow "poyo make api": Create on Products uses CreateProductsRequest.
+func NewServiceContext(domain string, actions []string) ClientContext {
+	domain = naming.Pascal(domain)
+	ctx := ClientContext{
+		Name:     domain,
+		File:     "src/services/" + naming.Kebab(domain) + ".service.ts",
+		Service:  naming.Camel(domain) + "Service",
+		Endpoint: constantCase(domain),
+		QueryKey: naming.Kebab(domain),
+	}
+	for _, a := range actions {
+		a = naming.Pascal(a)
+		verb := strings.ToLower(httpVerb(a))
+		ctx.Actions = append(ctx.Actions, ClientAction{
+			Name:     a,
+			Method:   naming.Camel(a),
+			Verb:     verb,
+			Body:     verb == "post" || verb == "put",
+			Endpoint: constantCase(a),
+			Path:     "/api/" + domain + "/" + a,
+			Request:  a + domain + "Request",
+			Response: a + domain + "Response",
+		})
+	}
+	return ctx
+}
+
+// NewAPIHookContext builds the context of a React Query hook calling action on the domain's service:
+// ("Products", "Create") -> useCreateProducts.
+func NewAPIHookContext(domain, action string) ClientContext {
+	ctx := NewServiceContext(domain, []string{action})
+	ctx.Action = ctx.Actions[0]
+	ctx.Hook = "use" + ctx.Action.Name + ctx.Name
+	return ctx
+}
+
+// IsQuery reports whether an API hook is a query (useQuery) rather than a mutation, from the HTTP
+// method of its action.
+func (c ClientContext) IsQuery() bool {
+	return c.Action.Verb == "get"
+}
+
+// APIHookFile returns the file of an API hook relative to the client project:
+// src/hooks-api/products/mutations/use-create-products.ts.
+func APIHookFile(ctx ClientContext, query bool) string {
+	kind := "mutations"
+	if query {
+		kind = "queries"
+	}
+	return path.Join("src", "hooks-api", naming.Kebab(ctx.Name), kind, naming.Kebab(ctx.Hook)+".ts")
+}
+
+// writeClientFile renders tmpl into ctx.File, refusing to overwrite an existing file.
+func writeClientFile(tmpl, label string, ctx ClientContext) error {
+	full := filepath.Join(config.ClientDir, filepath.FromSlash(ctx.File))
+	if _, err := os.Stat(full); err == nil {
+		return output.Errorf(output.ErrInvalidArgument, "%s already exists: %s", label, ctx.File)
+	}
+	content, err := Render(tmpl, ctx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	if err := editorconfig.WriteFile(full, content); err != nil {
+		return err
+	}
+	output.Created(full, "[CREATED] %s: %s\n", label, ctx.File)
+	return nil
+}
+
+// importPath returns the module of file relative to dir, without extension: "./forms/text-field".
+func importPath(dir, file string) string {
+	rel, err := filepath.Rel(dir, filepath.Join(config.ClientDir, filepath.FromSlash(file)))
+	if err != nil {
+		rel = file
+	}
+	rel = filepath.ToSlash(rel)
+	return "./" + strings.TrimSuffix(rel, path.Ext(rel))
+}
+
+// MakeComponent writes a component and exports it from src/components/index.ts.
+func MakeComponent(ctx ClientContext) error {
+	if err := writeClientFile(ComponentTmpl, "Component", ctx); err != nil {
+		return err
+	}
+	dir := clientSrc("components")
+	_, err := AddExport(filepath.Join(dir, "index.ts"), fmt.Sprintf("export { %s } from %q;", ctx.Name, importPath(dir, ctx.File)))
+	return err
+}
+
+// MakeHook writes a hook and exports it from src/hooks/index.ts.
+func MakeHook(ctx ClientContext) error {
+	if err := writeClientFile(HookTmpl, "Hook", ctx); err != nil {
+		return err
+	}
+	dir := clientSrc("hooks")
+	_, err := AddExport(filepath.Join(dir, "index.ts"), fmt.Sprintf("export { %s } from %q;", ctx.Hook, importPath(dir, ctx.File)))
+	return err
+}
+
+// MakeService writes the service of an API domain, adds its endpoints to src/lib/api/endpoints.ts
+// and exports it from src/services/index.ts.
+func MakeService(ctx ClientContext) error {
+	if err := writeClientFile(ServiceTmpl, "Service", ctx); err != nil {
+		return err
+	}
+	if err := addEndpoints(ctx); err != nil {
+		return err
+	}
+	dir := clientSrc("services")
+	_, err := AddExport(filepath.Join(dir, "index.ts"), fmt.Sprintf("export * from %q;", importPath(dir, ctx.File)))
+	return err
+}
+
+// MakeAPIHook writes an API query or mutation hook and exports it through the barrels of its folder,
+// its domain and src/hooks-api.
+func MakeAPIHook(ctx ClientContext, query bool) error {
+	tmpl, label := APIMutationTmpl, "API Mutation"
+	if query {
+		tmpl, label = APIQueryTmpl, "API Query"
+	}
+	ctx.File = APIHookFile(ctx, query)
+	if err := writeClientFile(tmpl, label, ctx); err != nil {
+		return err
+	}
+
+	// hooks-api/products/mutations/index.ts -> hooks-api/products/index.ts -> hooks-api/index.ts
+	dir := filepath.Dir(filepath.Join(config.ClientDir, filepath.FromSlash(ctx.File)))
+	module := importPath(dir, ctx.File)
+	for dir != clientSrc() {
+		if _, err := AddExport(filepath.Join(dir, "index.ts"), fmt.Sprintf("export * from %q;", module)); err != nil {
+			return err
+		}
+		module = "./" + filepath.Base(dir)
+		dir = filepath.Dir(dir)
+	}
+
+	service := clientSrc("services", naming.Kebab(ctx.Name)+".service.ts")
+	data, err := os.ReadFile(service)
+	if err != nil || !regexp.MustCompile(`(?m)^\s+`+regexp.QuoteMeta(ctx.Action.Method)+`\s*:`).Match(data) {
+		output.Printf("[WARN] %s.%s not found: poyo make service %s --actions %s\n", ctx.Service, ctx.Action.Method, ctx.Name, ctx.Action.Name)
+		output.Warn("%s.%s not found in %s", ctx.Service, ctx.Action.Method, fsutil.RelToRoot(service))
+	}
+	return nil
+}
+
+var endpointsRe = regexp.MustCompile(`(?m)^export const ENDPOINTS = \{[ \t]*\r?\n`)
+
+// addEndpoints adds the paths of a service's actions to ENDPOINTS, keeping the keys already there.
+func addEndpoints(ctx ClientContext) error {
+	file := clientSrc("lib", "api", "endpoints.ts")
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	content := string(data)
+	loc := endpointsRe.FindStringIndex(content)
+	if loc == nil {
+		return fmt.Errorf("ENDPOINTS not found in %s", fsutil.RelToRoot(file))
+	}
+	end := strings.Index(content[loc[1]:], "\n}")
+	if end < 0 {
+		return fmt.Errorf("end of ENDPOINTS not found in %s", fsutil.RelToRoot(file))
+	}
+	end += loc[1] + 1
+
+	// Keys are indented like the first one, or with the project's indentation in an empty ENDPOINTS
+	unit := editorconfig.For(file).Indent()
+	if first := content[loc[1]:end]; strings.TrimLeft(first, " \t") != first {
+		unit = first[:len(first)-len(strings.TrimLeft(first, " \t"))]
+	}
+	if unit == "" {
+		unit = "\t"
+	}
+	q := regexp.QuoteMeta(unit)
+
+	var added []string
+	block := regexp.MustCompile(`(?m)^` + q + ctx.Endpoint + `: \{[ \t]*\r?\n`).FindStringIndex(content[loc[1]:end])
+	if block == nil {
+		var b strings.Builder
+		b.WriteString(unit + ctx.Endpoint + ": {\n")
+		for _, a := range ctx.Actions {
+			b.WriteString(fmt.Sprintf("%s%s: %q,\n", unit+unit, a.Endpoint, a.Path))
+			added = append(added, a.Endpoint)
+		}
+		b.WriteString(unit + "},\n")
+		content = content[:end] + b.String() + content[end:]
+	} else {
+		start := loc[1] + block[1]
+		stop := strings.Index(content[start:], "\n"+unit+"}")
+		if stop < 0 {
+			return fmt.Errorf("end of ENDPOINTS.%s not found in %s", ctx.Endpoint, fsutil.RelToRoot(file))
+		}
+		stop += start + 1
+		var b strings.Builder
+		for _, a := range ctx.Actions {
+			if regexp.MustCompile(`(?m)^` + q + q + a.Endpoint + `:`).MatchString(content[start:stop]) {
+				continue
+			}
+			b.WriteString(fmt.Sprintf("%s%s: %q,\n", unit+unit, a.Endpoint, a.Path))
+			added = append(added, a.Endpoint)
+		}
+		content = content[:stop] + b.String() + content[stop:]
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	if err := editorconfig.UpdateFile(file, content); err != nil {
+		return err
+	}
+	output.Modified(file, "[UPDATED] Endpoints: %s (Added %s.%s)\n", fsutil.RelToRoot(file), ctx.Endpoint, strings.Join(added, ", "+ctx.Endpoint+"."))
+	return nil
+}
@@ -0,0 +1,28 @@
+package scaffold
+
+import "testing"
+
+func TestNewHookContext(t *testing.T) {
+	tests := []struct {
+		name, hook, file string
+	}{
+		{"page", "usePage", "src/hooks/use-page.ts"},
+		{"usePage", "usePage", "src/hooks/use-page.ts"},
+		{"use-page", "usePage", "src/hooks/use-page.ts"},
+		{"use_page", "usePage", "src/hooks/use-page.ts"},
+		{"user", "useUser", "src/hooks/use-user.ts"},
+		{"useful", "useUseful", "src/hooks/use-useful.ts"},
+		{"use", "useUse", "src/hooks/use-use.ts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := NewHookContext(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ctx.Hook != tt.hook || ctx.File != tt.file {
+				t.Errorf("NewHookContext(%q) = %s in %s, want %s in %s", tt.name, ctx.Hook, ctx.File, tt.hook, tt.file)
+			}
+		})
+	}
+}
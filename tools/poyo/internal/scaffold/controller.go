@@ -2,23 +2,39 @@ package scaffold
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/csharp"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/output"
 )
 
-func EnsureController(path, name, action, view string) (string, error) {
-	if !strings.HasSuffix(name, "Controller") {
-		name += "Controller"
-	}
+// EnsureController creates the controller of ctx at ctx.ControllerPath, or injects ctx.Action into it,
+// and returns the controller class name.
+func EnsureController(ctx Context) (string, error) {
+	name, action := ctx.Controller, ctx.Action
 
-	file := filepath.Join(path, name+".cs")
+	file := filepath.Join(config.ServerDir, filepath.FromSlash(ctx.ControllerPath))
 
 	// Create if not exists
 	if _, err := os.Stat(file); err != nil {
-		content := ControllerTemplate(name, action, view)
-		return name, os.WriteFile(file, []byte(content), 0644)
+		content, err := ControllerTemplate(ctx)
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return "", err
+		}
+		if err := editorconfig.WriteFile(file, content); err != nil {
+			return "", err
+		}
+		output.Created(file, "")
+		return name, nil
 	}
 
 	// Read existing
@@ -28,18 +44,46 @@ func EnsureController(path, name, action, view string) (string, error) {
 	}
 	content := string(data)
 
-	// Check if action exists
-	re := regexp.MustCompile(`(?i)IActionResult\s+` + regexp.QuoteMeta(action) + `\s*\(`)
-	if re.MatchString(content) {
+	// Add the action to the controller class, not to a helper type declared after it
+	f := csharp.Parse(content)
+	class := f.Type(name)
+	if class == nil || class.Open < 0 {
+		return "", fmt.Errorf("class %s not found in %s", name, fsutil.RelToRoot(file))
+	}
+	if _, ok := class.Method(action); ok {
 		return "", errors.New("action already exists")
 	}
 
-	// Inject action before last brace
-	idx := strings.LastIndex(content, "}")
-	if idx == -1 {
-		return "", errors.New("invalid controller file")
+	method, err := ActionTemplate(ctx)
+	if err != nil {
+		return "", err
 	}
-
-	out := content[:idx] + ActionTemplate(action, view) + content[idx:]
-	return name, os.WriteFile(file, []byte(out), 0644)
+	// New actions go after the existing ones, private helpers stay at the bottom
+	out := f.InsertMember(class, method)
+	for i := len(class.Members) - 1; i >= 0; i-- {
+		if m := class.Members[i]; m.Kind == csharp.Method && m.HasModifier("public") && !m.HasAttribute("NonAction") {
+			out = f.InsertMemberAfter(class, m, method)
+			break
+		}
+	}
+	if _, ok := class.Method("ApplySeo"); !ok && strings.Contains(method, "ApplySeo(") {
+		helper, err := Render(ApplySeoTmpl, ctx)
+		if err != nil {
+			return "", err
+		}
+		f = csharp.Parse(out)
+		out = f.InsertMember(f.Type(name), helper)
+	}
+	if strings.Contains(method, "[Authorize]") || strings.Contains(method, "[AllowAnonymous]") {
+		out = ensureUsing(out, "Microsoft.AspNetCore.Authorization")
+	}
+	if ctx.Data != "" {
+		out = ensureUsing(out, "System.Text.Json")
+		out = ensureUsing(out, ctx.DataNamespace)
+	}
+	if err := editorconfig.UpdateFile(file, out); err != nil {
+		return "", err
+	}
+	output.Modified(file, "")
+	return name, nil
 }
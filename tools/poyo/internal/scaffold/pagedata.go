@@ -0,0 +1,362 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/csharp"
+	"poyo-cli/internal/dotnet"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+)
+
+// Field is a property of a page's server data, shared by the C# record and the TS interface.
+type Field struct {
+	Name     string // C# property name, e.g. "CreatedAt"
+	Type     string // C# type, e.g. "DateTime", "int?", "List<string>"
+	Nullable bool
+}
+
+// DefaultFields is used when --data is given without --fields.
+var DefaultFields = []Field{{Name: "Message", Type: "string"}}
+
+// JSON returns the property name as serialized with JsonSerializerOptions.Web and read in TS.
+func (f Field) JSON() string {
+	return naming.Camel(f.Name)
+}
+
+// TSType returns the TypeScript type matching the C# type.
+func (f Field) TSType() string {
+	t := tsType(strings.TrimSuffix(f.Type, "?"))
+	if f.Nullable {
+		t += " | null"
+	}
+	return t
+}
+
+// Default returns a C# expression used for the property in the generated action.
+func (f Field) Default() string {
+	if f.Nullable {
+		return "null"
+	}
+	t := f.Type
+	switch {
+	case t == "string":
+		return `""`
+	case t == "bool":
+		return "false"
+	case isNumber(t):
+		return "0"
+	case t == "DateTime" || t == "DateTimeOffset":
+		return t + ".UtcNow"
+	case t == "Guid":
+		return "Guid.Empty"
+	case strings.HasSuffix(t, "[]") || strings.HasPrefix(t, "List<") || strings.HasPrefix(t, "IReadOnlyList<") || strings.HasPrefix(t, "IEnumerable<"):
+		return "[]"
+	}
+	return "default!"
+}
+
+var fieldRe = regexp.MustCompile(`^([A-Za-z_]\w*)\s*:\s*(.+)$`)
+
+// ParseFields parses "message:string,count:int,tags:string[]" into fields.
+// Types are C# types; commas inside generic arguments are allowed.
+func ParseFields(spec string) ([]Field, error) {
+	var fields []Field
+	for _, part := range splitTopLevel(spec) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		m := fieldRe.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("invalid field %q (expected name:type, e.g. count:int)", part)
+		}
+		t := strings.TrimSpace(m[2])
+		fields = append(fields, Field{Name: naming.Pascal(m[1]), Type: t, Nullable: strings.HasSuffix(t, "?")})
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields in %q", spec)
+	}
+	return fields, nil
+}
+
+// WithData returns a copy of ctx describing typed server data with the given fields.
+func (c Context) WithData(rt routes.Route, fields []Field) Context {
+	c.Data = dataName(rt)
+	c.DataNamespace = dotnet.ServerNamespace(filepath.Join(config.ServerDir, path.Dir(DataCSFile(rt))))
+	c.DataImport = "./" + strings.TrimSuffix(path.Base(DataTSFile(rt)), ".ts")
+	c.Fields = fields
+	return c
+}
+
+// DataCSFile returns the server data record of a route, relative to the server project:
+// Models/Pages/<parents>/<Name>Data.cs.
+func DataCSFile(rt routes.Route) string {
+	segments := naming.Segments(rt.Name)
+	parts := append([]string{"Models", "Pages"}, parentSegments(segments)...)
+	return path.Join(append(parts, dataName(rt)+".cs")...)
+}
+
+// dataName returns the record and interface name: "Orders/Edit" -> "OrdersEditData".
+func dataName(rt routes.Route) string {
+	var b strings.Builder
+	for _, s := range naming.Segments(rt.Name) {
+		b.WriteString(naming.Pascal(s))
+	}
+	return b.String() + "Data"
+}
+
+// DataTSFile returns the TS interface file of a route next to its page, relative to the client project:
+// src/pages/Users/index.page.tsx -> src/pages/Users/index.data.ts.
+func DataTSFile(rt routes.Route) string {
+	return strings.TrimSuffix(rt.Files.React, ".page.tsx") + ".data.ts"
+}
+
+// ScaffoldPageData writes the C# record and TS interface of ctx.Data if they do not exist yet.
+func ScaffoldPageData(rt routes.Route, ctx Context) error {
+	files := []struct {
+		tmpl, base, rel, label string
+	}{
+		{PageDataCSTmpl, config.ServerDir, DataCSFile(rt), "Server Data"},
+		{PageDataTSTmpl, config.ClientDir, DataTSFile(rt), "Page Data Type"},
+	}
+	for _, f := range files {
+		full := filepath.Join(f.base, f.rel)
+		if _, err := os.Stat(full); err == nil {
+			output.Printf("[EXISTS] %s: %s\n", f.label, f.rel)
+			continue
+		}
+		content, err := Render(f.tmpl, ctx)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return err
+		}
+		if err := editorconfig.WriteFile(full, content); err != nil {
+			return err
+		}
+		output.Created(full, "[CREATED] %s: %s\n", f.label, f.rel)
+	}
+	return nil
+}
+
+var (
+	returnViewRe = regexp.MustCompile(`(?m)^([ \t]*)return\s+View\(`)
+	usingRe      = regexp.MustCompile(`(?m)^using\s+[\w.]+;[ \t]*\r?\n`)
+)
+
+// InjectServerData sets ViewBag.ServerData in an existing controller action, before its "return View(".
+// It reports false if the action already sets server data.
+func InjectServerData(file string, ctx Context) (bool, error) {
+	actions, content, err := controllers.ParseFile(file)
+	if err != nil {
+		return false, err
+	}
+	action, ok := controllers.Find(actions, ctx.Action)
+	if !ok {
+		return false, fmt.Errorf("action %s not found in %s", ctx.Action, filepath.Base(file))
+	}
+	body := content[action.Start:action.End]
+	if strings.Contains(body, "ViewBag.ServerData") {
+		return false, nil
+	}
+	m := returnViewRe.FindStringSubmatchIndex(body)
+	if m == nil {
+		return false, fmt.Errorf("no 'return View(' in %s.%s", ctx.Controller, ctx.Action)
+	}
+
+	statements, err := Render(ServerDataTmpl, ctx)
+	if err != nil {
+		return false, err
+	}
+	// The statements go at the indentation of the return, one level below the method's
+	at := action.Start + m[0]
+	indent, member := body[m[2]:m[3]], csharp.Indent(content, action.Start)
+	unit := csharp.Parse(content).IndentUnit()
+	if strings.HasPrefix(indent, member) && len(indent) > len(member) {
+		unit = indent[len(member):]
+	}
+	out := content[:at] + csharp.Reindent(statements, indent, unit, "\n") + content[at:]
+	out = ensureUsing(out, "System.Text.Json")
+	out = ensureUsing(out, ctx.DataNamespace)
+
+	if err := editorconfig.UpdateFile(file, out); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ensureUsing adds "using ns;" after the last using directive if it is missing.
+func ensureUsing(content, ns string) string {
+	directive := "using " + ns + ";"
+	if strings.Contains(content, directive) {
+		return content
+	}
+	all := usingRe.FindAllStringIndex(content, -1)
+	if len(all) == 0 {
+		return directive + "\n" + content
+	}
+	end := all[len(all)-1][1]
+	return content[:end] + directive + "\n" + content[end:]
+}
+
+var (
+	usePageCallRe = regexp.MustCompile(`usePage(?:<[^>()]*>)?\(\)`)
+	lastImportRe  = regexp.MustCompile(`(?m)^import\s[^;]*;[ \t]*\r?\n`)
+)
+
+// WirePage makes the page read its server data with usePage<ctx.Data>(). An existing usePage() call
+// is retyped; otherwise a "const data = usePage<...>()" line is added to the component.
+func WirePage(file string, ctx Context) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	content := string(data)
+	typed := "usePage<" + ctx.Data + ">()"
+	if strings.Contains(content, typed) {
+		if !strings.Contains(content, ctx.DataImport+"\"") && !strings.Contains(content, ctx.DataImport+"'") {
+			output.Printf("[INFO] %s declares its own %s; import it from %s instead\n", filepath.Base(file), ctx.Data, ctx.DataImport)
+			output.Warn("%s declares its own %s; import it from %s instead", fsutil.RelToRoot(file), ctx.Data, ctx.DataImport)
+		}
+		return false, nil
+	}
+
+	if usePageCallRe.MatchString(content) {
+		content = usePageCallRe.ReplaceAllString(content, typed)
+	} else {
+		body, indent, ok := componentBody(content)
+		if !ok {
+			return false, fmt.Errorf("no default exported component found in %s", filepath.Base(file))
+		}
+		content = content[:body] + "\n" + indent + "const data = " + typed + ";\n" + content[body:]
+	}
+
+	imports := ""
+	if !regexp.MustCompile(`import\s+type\s*\{[^}]*\b` + regexp.QuoteMeta(ctx.Data) + `\b`).MatchString(content) {
+		imports = "import type { " + ctx.Data + " } from \"" + ctx.DataImport + "\";\n"
+	}
+	if !regexp.MustCompile(`import\s*\{[^}]*\busePage\b`).MatchString(content) {
+		imports = "import { usePage } from \"~/hooks\";\n" + imports
+	}
+	at := 0
+	if all := lastImportRe.FindAllStringIndex(content, -1); len(all) > 0 {
+		at = all[len(all)-1][1]
+	}
+	content = content[:at] + imports + content[at:]
+
+	if err := editorconfig.UpdateFile(file, content); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+var defaultExportRe = regexp.MustCompile(`export\s+default\s+(?:function\s+)?([A-Z]\w*)`)
+
+// componentBody returns the offset just after the opening brace of the default exported component's
+// function body and the indentation of its first statement.
+func componentBody(content string) (int, string, bool) {
+	export := defaultExportRe.FindStringSubmatch(content)
+	if export == nil {
+		return 0, "", false
+	}
+	name := regexp.QuoteMeta(export[1])
+	re := regexp.MustCompile(`(?:function\s+` + name + `\s*\([^)]*\)[^{]*|const\s+` + name + `\b[^=]*=\s*\([^)]*\)\s*=>\s*)\{`)
+	m := re.FindStringIndex(content)
+	if m == nil {
+		return 0, "", false
+	}
+	indent := "\t"
+	if rest := content[m[1]:]; strings.HasPrefix(rest, "\n") {
+		line := strings.SplitN(rest[1:], "\n", 2)[0]
+		if trimmed := strings.TrimLeft(line, " \t"); trimmed != "" {
+			indent = line[:len(line)-len(trimmed)]
+		}
+	}
+	return m[1], indent, true
+}
+
+func parentSegments(segments []string) []string {
+	if len(segments) <= 1 {
+		return nil
+	}
+	parents := make([]string, 0, len(segments)-1)
+	for _, s := range segments[:len(segments)-1] {
+		parents = append(parents, naming.Pascal(s))
+	}
+	return parents
+}
+
+func isNumber(t string) bool {
+	switch t {
+	case "int", "long", "short", "byte", "float", "double", "decimal", "uint", "ulong", "ushort", "sbyte":
+		return true
+	}
+	return false
+}
+
+func tsType(t string) string {
+	switch {
+	case t == "string" || t == "DateTime" || t == "DateTimeOffset" || t == "DateOnly" || t == "TimeOnly" || t == "Guid" || t == "char":
+		return "string"
+	case t == "bool":
+		return "boolean"
+	case isNumber(t):
+		return "number"
+	case t == "object":
+		return "unknown"
+	case strings.HasSuffix(t, "[]"):
+		return arrayOf(tsType(strings.TrimSuffix(t, "[]")))
+	}
+	if i := strings.Index(t, "<"); i > 0 && strings.HasSuffix(t, ">") {
+		generic, args := t[:i], splitTopLevel(t[i+1:len(t)-1])
+		switch generic {
+		case "List", "IList", "IReadOnlyList", "IEnumerable", "ICollection", "IReadOnlyCollection", "HashSet":
+			return arrayOf(tsType(strings.TrimSpace(args[0])))
+		case "Dictionary", "IDictionary", "IReadOnlyDictionary":
+			if len(args) == 2 {
+				return "Record<" + tsType(strings.TrimSpace(args[0])) + ", " + tsType(strings.TrimSpace(args[1])) + ">"
+			}
+		}
+	}
+	// Other records and classes keep their name; declare them on the TS side by hand.
+	return t
+}
+
+func arrayOf(t string) string {
+	if strings.Contains(t, " ") {
+		return "(" + t + ")[]"
+	}
+	return t + "[]"
+}
+
+// splitTopLevel splits on commas that are not inside <...>.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,99 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWirePage(t *testing.T) {
+	ctx := Context{Data: "OrdersEditData", DataImport: "./index.data"}
+	tests := []struct {
+		name    string
+		page    string
+		want    string
+		changed bool
+	}{
+		{
+			name:    "untyped call",
+			page:    "import { usePage } from \"~/hooks\";\n\nexport default function EditPage() {\n\tconst data = usePage();\n\treturn null;\n}\n",
+			want:    "import { usePage } from \"~/hooks\";\nimport type { OrdersEditData } from \"./index.data\";\n\nexport default function EditPage() {\n\tconst data = usePage<OrdersEditData>();\n\treturn null;\n}\n",
+			changed: true,
+		},
+		{
+			name:    "import already present",
+			page:    "import { usePage } from \"~/hooks\";\nimport type { OrdersEditData } from \"./index.data\";\n\nexport default function EditPage() {\n\tconst data = usePage<EditData>();\n\treturn null;\n}\n",
+			want:    "import { usePage } from \"~/hooks\";\nimport type { OrdersEditData } from \"./index.data\";\n\nexport default function EditPage() {\n\tconst data = usePage<OrdersEditData>();\n\treturn null;\n}\n",
+			changed: true,
+		},
+		{
+			name:    "no call",
+			page:    "export default function EditPage() {\n\treturn null;\n}\n",
+			want:    "import { usePage } from \"~/hooks\";\nimport type { OrdersEditData } from \"./index.data\";\nexport default function EditPage() {\n\tconst data = usePage<OrdersEditData>();\n\n\treturn null;\n}\n",
+			changed: true,
+		},
+		{
+			name:    "already wired",
+			page:    "import { usePage } from \"~/hooks\";\nimport type { OrdersEditData } from \"./index.data\";\n\nexport default function EditPage() {\n\tconst data = usePage<OrdersEditData>();\n}\n",
+			want:    "import { usePage } from \"~/hooks\";\nimport type { OrdersEditData } from \"./index.data\";\n\nexport default function EditPage() {\n\tconst data = usePage<OrdersEditData>();\n}\n",
+			changed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "index.page.tsx")
+			if err := os.WriteFile(file, []byte(tt.page), 0644); err != nil {
+				t.Fatal(err)
+			}
+			changed, err := WirePage(file, ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := os.ReadFile(file)
+			if changed != tt.changed || string(got) != tt.want {
+				t.Errorf("WirePage() = %v\n%s\nwant %v\n%s", changed, got, tt.changed, tt.want)
+			}
+			if n := strings.Count(string(got), "import type"); n != 1 {
+				t.Errorf("%d type imports, want 1", n)
+			}
+		})
+	}
+}
+
+func TestInjectServerData(t *testing.T) {
+	ctx := Context{Controller: "OrdersController", Action: "Edit", Data: "OrdersEditData", DataNamespace: "App.PageData",
+		Fields: []Field{{Name: "Id", Type: "int"}, {Name: "Title", Type: "string"}}}
+	tests := []struct {
+		name       string
+		controller string
+		want       string
+	}{
+		{
+			name:       "spaces",
+			controller: "using System.Text.Json;\nusing App.PageData;\n\nnamespace App;\n\npublic class OrdersController : Controller\n{\n    public IActionResult Edit()\n    {\n        return View(\"~/Views/Orders/Edit.cshtml\");\n    }\n}\n",
+			want:       "using System.Text.Json;\nusing App.PageData;\n\nnamespace App;\n\npublic class OrdersController : Controller\n{\n    public IActionResult Edit()\n    {\n        var data = new OrdersEditData(\n            Id: 0,\n            Title: \"\"\n        );\n        ViewBag.ServerData = JsonSerializer.Serialize(data, JsonSerializerOptions.Web);\n        return View(\"~/Views/Orders/Edit.cshtml\");\n    }\n}\n",
+		},
+		{
+			name:       "tabs in a block namespace",
+			controller: "using System.Text.Json;\nusing App.PageData;\n\nnamespace App\n{\n\tpublic class OrdersController : Controller\n\t{\n\t\tpublic IActionResult Edit()\n\t\t{\n\t\t\treturn View(\"~/Views/Orders/Edit.cshtml\");\n\t\t}\n\t}\n}\n",
+			want:       "using System.Text.Json;\nusing App.PageData;\n\nnamespace App\n{\n\tpublic class OrdersController : Controller\n\t{\n\t\tpublic IActionResult Edit()\n\t\t{\n\t\t\tvar data = new OrdersEditData(\n\t\t\t\tId: 0,\n\t\t\t\tTitle: \"\"\n\t\t\t);\n\t\t\tViewBag.ServerData = JsonSerializer.Serialize(data, JsonSerializerOptions.Web);\n\t\t\treturn View(\"~/Views/Orders/Edit.cshtml\");\n\t\t}\n\t}\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "OrdersController.cs")
+			if err := os.WriteFile(file, []byte(tt.controller), 0644); err != nil {
+				t.Fatal(err)
+			}
+			changed, err := InjectServerData(file, ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := os.ReadFile(file)
+			if !changed || string(got) != tt.want {
+				t.Errorf("InjectServerData() = %v\n%s\nwant\n%s", changed, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,15 +1,21 @@
 package scaffold
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"poyo-cli/internal/config"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/output"
 	"poyo-cli/internal/routes"
 )
 
 type ScaffoldOptions struct {
-	NoView bool
+	NoView   bool
+	Layout   string  // Razor layout set in the generated view, e.g. "_Layout". Empty uses _ViewStart.
+	Template string  // Page variant, e.g. "list". Empty uses the blank page.
+	Fields   []Field // Typed server data (--data). Nil when the page has none.
+	// Generate a page test and, for routes with a custom controller, an xUnit test (--with-tests)
+	WithTests bool
 }
 
 type ControllerInfo struct {
@@ -17,7 +23,17 @@ type ControllerInfo struct {
 	Action string
 }
 
-func ScaffoldRouteFiles(name string, files routes.Files, options ScaffoldOptions, controller *ControllerInfo) error {
+func ScaffoldRouteFiles(rt routes.Route, options ScaffoldOptions, controller *ControllerInfo) error {
+	files := rt.Files
+	ctx := NewContext(rt)
+	ctx.Layout = options.Layout
+	if options.Fields != nil {
+		ctx = ctx.WithData(rt, options.Fields)
+		if err := ScaffoldPageData(rt, ctx); err != nil {
+			return err
+		}
+	}
+
 	pageFullPath := filepath.Join(config.ClientDir, files.React)
 	viewFullPath := filepath.Join(config.ServerDir, files.View)
 
@@ -26,12 +42,22 @@ func ScaffoldRouteFiles(name string, files routes.Files, options ScaffoldOptions
 		if err := os.MkdirAll(filepath.Dir(pageFullPath), 0755); err != nil {
 			return err
 		}
-		if err := os.WriteFile(pageFullPath, []byte(ReactPage(name)), 0644); err != nil {
+		content, err := ReactPage(ctx, options.Template)
+		if err != nil {
+			return err
+		}
+		if err := editorconfig.WriteFile(pageFullPath, content); err != nil {
 			return err
 		}
-		fmt.Printf("[CREATED] React Page: %s\n", files.React)
+		output.Created(pageFullPath, "[CREATED] React Page: %s\n", files.React)
+		// Variants without server data support get a usePage<T>() call added
+		if ctx.Data != "" {
+			if _, err := WirePage(pageFullPath, ctx); err != nil {
+				return err
+			}
+		}
 	} else {
-		fmt.Printf("[EXISTS] React Page: %s\n", files.React)
+		output.Printf("[EXISTS] React Page: %s\n", files.React)
 	}
 
 	// 2. MVC View
@@ -40,34 +66,51 @@ func ScaffoldRouteFiles(name string, files routes.Files, options ScaffoldOptions
 			if err := os.MkdirAll(filepath.Dir(viewFullPath), 0755); err != nil {
 				return err
 			}
-			if err := os.WriteFile(viewFullPath, []byte(MVCView(name)), 0644); err != nil {
+			content, err := MVCView(ctx)
+			if err != nil {
 				return err
 			}
-			fmt.Printf("[CREATED] MVC View: %s\n", files.View)
+			if err := editorconfig.WriteFile(viewFullPath, content); err != nil {
+				return err
+			}
+			output.Created(viewFullPath, "[CREATED] MVC View: %s\n", files.View)
 		} else {
-			fmt.Printf("[EXISTS] MVC View: %s\n", files.View)
+			output.Printf("[EXISTS] MVC View: %s\n", files.View)
 		}
 	} else {
-		fmt.Println("[SKIP] MVC View generation skipped (--no-view)")
+		output.Println("[SKIP] MVC View generation skipped (--no-view)")
 	}
 
 	// 3. Controller Injection
 	if controller != nil {
-		_, err := EnsureController(
-			config.ControllersDir,
-			controller.Name,
-			controller.Action,
-			files.View,
-		)
+		rt.Controller, rt.Action = controller.Name, controller.Action
+		actionCtx := NewContext(rt)
+		if options.Fields != nil {
+			actionCtx = actionCtx.WithData(rt, options.Fields)
+		}
+		_, err := EnsureController(actionCtx)
 		if err != nil {
 			// If action exists, we just log it, not fail everything
 			if err.Error() == "action already exists" {
-				fmt.Printf("[INFO] Action '%s' already exists in %sController\n", controller.Action, controller.Name)
+				output.Printf("[INFO] Action '%s' already exists in %s\n", controller.Action, actionCtx.Controller)
 			} else {
 				return err
 			}
 		} else {
-			fmt.Printf("[UPDATED] Controller: %sController.cs (Injected action '%s')\n", controller.Name, controller.Action)
+			output.Printf("[UPDATED] Controller: %s (Injected action '%s')\n", actionCtx.ControllerPath, controller.Action)
+		}
+	}
+
+	// 4. Tests
+	if options.WithTests {
+		testCtx := NewContext(rt).WithTests(rt)
+		if err := ScaffoldPageTest(rt, testCtx); err != nil {
+			return err
+		}
+		if rt.Controller != "" && rt.Action != "" {
+			if err := ScaffoldControllerTest(testCtx); err != nil {
+				return err
+			}
 		}
 	}
 
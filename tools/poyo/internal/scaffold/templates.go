@@ -1,60 +1,272 @@
 package scaffold
 
 import (
+	"bytes"
+	"embed"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/controllers"
+	"poyo-cli/internal/dotnet"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/naming"
+	"poyo-cli/internal/routes"
+)
+
+// Built-in templates. A file with the same name in config.TemplatesDir (.poyo/templates) replaces one.
+const (
+	PageTmpl       = "page.tsx.tmpl"
+	ViewTmpl       = "view.cshtml.tmpl"
+	ControllerTmpl = "controller.cs.tmpl"
+	ActionTmpl     = "action.cs.tmpl"
+	PageDataCSTmpl = "page-data.cs.tmpl"
+	PageDataTSTmpl = "page-data.ts.tmpl"
+	ServerDataTmpl = "server-data.cs.tmpl"
+	ApplySeoTmpl   = "apply-seo.cs.tmpl"
 )
 
-func ReactPage(name string) string {
-	component := name
-	if strings.Contains(name, "/") {
-		parts := strings.Split(name, "/")
-		component = parts[len(parts)-1]
+// BlankVariant is the default page variant, rendered from page.tsx.tmpl.
+// Other variants such as "list" come from page-<variant>.tsx.tmpl.
+const BlankVariant = "blank"
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// Context is the data every scaffold template is executed with.
+type Context struct {
+	Route          string // Route path, e.g. "/Admin/UserSettings"
+	Name           string // Route name, e.g. "Admin/UserSettings" (data-page-name)
+	Component      string // React component name, e.g. "UserSettings"
+	Access         string // "protected", "public" or "guest-only"
+	IsPublic       bool
+	IsGuestOnly    bool
+	Controller     string            // Controller class, e.g. "AdminController". Empty for PageController routes
+	Action         string            // Controller action, e.g. "UserSettings"
+	Namespace      string            // Controller namespace from the .csproj and folder, e.g. "Poyo.Server.Controllers.Admin"
+	ControllerPath string            // Controller file relative to the server project, e.g. "Controllers/Admin/ReportsController.cs"
+	View           string            // View path relative to the server project, e.g. "Views/Admin/UserSettings/Index.cshtml"
+	React          string            // Page path relative to the client project
+	Layout         string            // Razor layout, empty to use _ViewStart
+	SEO            map[string]string // SEO entries from routes.json ("title", "description", ...)
+	RootNamespace  string            // Root namespace of the server project, e.g. "Poyo.Server"
+	SEOHelper      string            // Rendered apply-seo.cs.tmpl, available in the controller template
+
+	// Typed server data, set with --data or "make page-data". Data is empty when the page has none.
+	Data          string  // Record and TS interface name, e.g. "UserSettingsData"
+	DataNamespace string  // Namespace of the record, e.g. "Poyo.Server.Models.Pages"
+	DataImport    string  // Import path of the interface from the page, e.g. "./index.data"
+	Fields        []Field // Properties: {{.Name}}, {{.Type}}, {{.JSON}}, {{.TSType}}, {{.Default}}
+	ServerData    string  // Rendered server-data.cs.tmpl, available in controller and action templates
+
+	// Tests, set with --with-tests
+	PageImport    string // Import path of the page from its test, e.g. "./index.page"
+	TestNamespace string // Namespace of the controller's xUnit test class, e.g. "Poyo.Server.Tests.Controllers"
+}
+
+// NewContext builds the template context of a route.
+func NewContext(rt routes.Route) Context {
+	controller := rt.Controller
+	file := config.ControllersDir
+	if controller != "" {
+		file = controllers.Path(config.ControllersDir, controller)
+		controller = controllers.ClassName(controller)
+	}
+	seo := rt.SEO
+	if seo == nil {
+		seo = map[string]string{}
+	}
+	return Context{
+		Route:          rt.Path,
+		Name:           rt.Name,
+		Component:      naming.Component(rt.Name),
+		Access:         routes.Access(rt),
+		IsPublic:       rt.IsPublic,
+		IsGuestOnly:    rt.IsGuestOnly,
+		Controller:     controller,
+		Action:         rt.Action,
+		Namespace:      dotnet.ServerNamespace(filepath.Dir(file)),
+		View:           rt.Files.View,
+		ControllerPath: controllerPath(file, controller),
+		React:          rt.Files.React,
+		SEO:            seo,
+		RootNamespace:  dotnet.ServerNamespace(config.ServerDir),
+	}
+}
+
+// controllerPath returns a controller file relative to the server project, empty without a controller.
+func controllerPath(file, controller string) string {
+	if controller == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(config.ServerDir, file)
+	if err != nil {
+		return file
 	}
+	return filepath.ToSlash(rel)
+}
 
-	return fmt.Sprintf(`import type React from 'react';
+// funcs are available in every template, e.g. {{kebab .Component}}.
+var funcs = template.FuncMap{
+	"pascal": naming.Pascal,
+	"camel":  naming.Camel,
+	"kebab":  naming.Kebab,
+	"lower":  strings.ToLower,
+	"upper":  strings.ToUpper,
+}
 
-const %s: React.FC = () => {
-  return (
-    <div className="p-4">
-      <h1 className="text-2xl font-bold">%s</h1>
-    </div>
-  );
+// Render executes the named template, preferring the project's override in config.TemplatesDir.
+func Render(name string, ctx any) (string, error) {
+	source, origin, err := LoadTemplate(name)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=zero").Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", origin, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ctx); err != nil {
+		return "", fmt.Errorf("%s: %w", origin, err)
+	}
+	return buf.String(), nil
 }
 
-export default %s;
-`, component, name, component)
+// LoadTemplate returns the source of a template and where it came from (a project path or "built-in <name>").
+func LoadTemplate(name string) (source, origin string, err error) {
+	override := filepath.Join(config.TemplatesDir, name)
+	if data, err := os.ReadFile(override); err == nil {
+		return string(data), fsutil.RelToRoot(override), nil
+	}
+	data, err := builtinTemplates.ReadFile("templates/" + name)
+	if err != nil {
+		return "", "", fmt.Errorf("unknown template %q", name)
+	}
+	return string(data), "built-in " + name, nil
 }
 
-func MVCView(name string) string {
-	return fmt.Sprintf(`@{
-    ViewBag.Title = "%s";
+// ReactPage renders the page component of a route using a page variant ("" for blank).
+func ReactPage(ctx Context, variant string) (string, error) {
+	return Render(PageVariantTmpl(variant), ctx)
 }
 
-<div id="react-root" data-page-name="%s"></div>
-`, name, name)
+// PageVariantTmpl returns the template file of a page variant.
+func PageVariantTmpl(variant string) string {
+	if variant == "" || variant == BlankVariant {
+		return PageTmpl
+	}
+	return "page-" + variant + ".tsx.tmpl"
 }
 
-func ControllerTemplate(ctrl, action, view string) string {
-	return fmt.Sprintf(`using Microsoft.AspNetCore.Mvc;
+// TemplateInfo describes a built-in or project template.
+type TemplateInfo struct {
+	Name      string `json:"name"`
+	Variant   string `json:"variant,omitempty"`   // Page variant for route add --template
+	Source    string `json:"source"`              // "built-in" or "project"
+	Path      string `json:"path,omitempty"`      // Project file, relative to the project root
+	Overrides bool   `json:"overrides,omitempty"` // The project file replaces a built-in template
+}
 
-namespace Poyo.Server.Controllers;
+// Templates lists the built-in templates and the project's templates in config.TemplatesDir, sorted by name.
+func Templates() ([]TemplateInfo, error) {
+	byName := make(map[string]*TemplateInfo)
 
-public class %s : Controller
-{
-    public IActionResult %s()
-    {
-        return View("~/%s");
-    }
+	builtin, err := builtinTemplates.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range builtin {
+		byName[e.Name()] = &TemplateInfo{Name: e.Name(), Source: "built-in"}
+	}
+
+	project, err := os.ReadDir(config.TemplatesDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range project {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".tmpl") {
+			continue
+		}
+		_, overrides := byName[e.Name()]
+		byName[e.Name()] = &TemplateInfo{
+			Name:      e.Name(),
+			Source:    "project",
+			Path:      fsutil.RelToRoot(filepath.Join(config.TemplatesDir, e.Name())),
+			Overrides: overrides,
+		}
+	}
+
+	list := make([]TemplateInfo, 0, len(byName))
+	for _, t := range byName {
+		t.Variant = pageVariant(t.Name)
+		list = append(list, *t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// PageVariants returns the names accepted by route add --template.
+func PageVariants() []string {
+	templates, _ := Templates()
+	var variants []string
+	for _, t := range templates {
+		if t.Variant != "" {
+			variants = append(variants, t.Variant)
+		}
+	}
+	sort.Strings(variants)
+	return variants
 }
-`, ctrl, action, view)
+
+// HasPageVariant reports whether a built-in or project template exists for the page variant.
+func HasPageVariant(variant string) bool {
+	_, _, err := LoadTemplate(PageVariantTmpl(variant))
+	return err == nil
 }
 
-func ActionTemplate(action, view string) string {
-	return fmt.Sprintf(`
-    public IActionResult %s()
-    {
-        return View("~/%s");
-    }
-`, action, view)
+func pageVariant(file string) string {
+	if file == PageTmpl {
+		return BlankVariant
+	}
+	if strings.HasPrefix(file, "page-") && strings.HasSuffix(file, ".tsx.tmpl") {
+		return strings.TrimSuffix(strings.TrimPrefix(file, "page-"), ".tsx.tmpl")
+	}
+	return ""
+}
+
+// MVCView renders the Razor view hosting a route's page.
+func MVCView(ctx Context) (string, error) {
+	return Render(ViewTmpl, ctx)
+}
+
+// ControllerTemplate renders a new controller file containing ctx.Action.
+func ControllerTemplate(ctx Context) (string, error) {
+	return renderAction(ControllerTmpl, ctx)
+}
+
+// ActionTemplate renders an action method to inject into an existing controller.
+func ActionTemplate(ctx Context) (string, error) {
+	return renderAction(ActionTmpl, ctx)
+}
+
+func renderAction(name string, ctx Context) (string, error) {
+	helper, err := Render(ApplySeoTmpl, ctx)
+	if err != nil {
+		return "", err
+	}
+	ctx.SEOHelper = helper
+	if ctx.Data != "" {
+		statements, err := Render(ServerDataTmpl, ctx)
+		if err != nil {
+			return "", err
+		}
+		ctx.ServerData = statements
+	}
+	return Render(name, ctx)
 }
@@ -0,0 +1,211 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"poyo-cli/internal/config"
+	"poyo-cli/internal/csharp"
+	"poyo-cli/internal/dotnet"
+	"poyo-cli/internal/editorconfig"
+	"poyo-cli/internal/fsutil"
+	"poyo-cli/internal/output"
+	"poyo-cli/internal/routes"
+)
+
+const (
+	PageTestTmpl       = "page.test.tsx.tmpl"
+	ControllerTestTmpl = "controller-test.cs.tmpl"
+	ActionTestTmpl     = "action-test.cs.tmpl"
+	TestProjectTmpl    = "test-project.csproj.tmpl"
+)
+
+// clientTestDeps are the dev dependencies the page tests need.
+var clientTestDeps = []string{"vitest", "@testing-library/react", "jsdom"}
+
+// TestProjectContext is the data test-project.csproj.tmpl is executed with.
+type TestProjectContext struct {
+	TargetFramework string // From the server .csproj, e.g. "net10.0"
+	ServerProject   string // Server .csproj relative to the test project, e.g. "..\Poyo.Server\Poyo.Server.csproj"
+}
+
+// WithTests returns a copy of ctx with the names used by the test templates.
+func (c Context) WithTests(rt routes.Route) Context {
+	c.PageImport = "./" + strings.TrimSuffix(path.Base(rt.Files.React), ".tsx")
+	if c.ControllerPath != "" {
+		c.TestNamespace = testNamespace(path.Dir(c.ControllerPath))
+	}
+	return c
+}
+
+// TestTSFile returns the Vitest test of a route next to its page, relative to the client project:
+// src/pages/Users/index.page.tsx -> src/pages/Users/index.test.tsx.
+func TestTSFile(rt routes.Route) string {
+	return strings.TrimSuffix(rt.Files.React, ".page.tsx") + ".test.tsx"
+}
+
+// ServerTestsDir returns the xUnit project of the server: a "<server>.Tests" folder next to it.
+func ServerTestsDir() string {
+	return config.ServerDir + ".Tests"
+}
+
+// testFile returns the test class of a controller, mirroring its folder in the test project:
+// Controllers/Admin/ReportsController.cs -> <tests>/Controllers/Admin/ReportsControllerTests.cs.
+func testFile(controllerPath string) string {
+	return filepath.Join(ServerTestsDir(), filepath.FromSlash(strings.TrimSuffix(controllerPath, ".cs")+"Tests.cs"))
+}
+
+// testNamespace returns the namespace of tests for code in dir (relative to the server project).
+func testNamespace(dir string) string {
+	tests := ServerTestsDir()
+	p, err := dotnet.Load(tests)
+	if err != nil || p.Path == "" {
+		// Not created yet: named after the folder, like the .csproj it will get
+		p = dotnet.Project{Dir: tests, RootNamespace: dotnet.Identifier(filepath.Base(tests))}
+	}
+	return p.Namespace(filepath.Join(tests, filepath.FromSlash(dir)))
+}
+
+// ScaffoldPageTest writes the Vitest + Testing Library test of a page if it does not exist yet.
+func ScaffoldPageTest(rt routes.Route, ctx Context) error {
+	rel := TestTSFile(rt)
+	full := filepath.Join(config.ClientDir, rel)
+	if _, err := os.Stat(full); err == nil {
+		output.Printf("[EXISTS] Page Test: %s\n", rel)
+		return nil
+	}
+	content, err := Render(PageTestTmpl, ctx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	if err := editorconfig.WriteFile(full, content); err != nil {
+		return err
+	}
+	output.Created(full, "[CREATED] Page Test: %s\n", rel)
+
+	if missing := missingClientDeps(); len(missing) > 0 {
+		output.Printf("[WARN] Page tests need: npm install -D %s\n", strings.Join(missing, " "))
+		output.Warn("page tests need dev dependencies: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// missingClientDeps returns the test dependencies not listed in the client package.json.
+func missingClientDeps() []string {
+	data, err := os.ReadFile(filepath.Join(config.ClientDir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil
+	}
+	var missing []string
+	for _, dep := range clientTestDeps {
+		if pkg.Dependencies[dep] == "" && pkg.DevDependencies[dep] == "" {
+			missing = append(missing, dep)
+		}
+	}
+	return missing
+}
+
+// ScaffoldControllerTest adds an xUnit test of ctx.Action to the controller's test class, creating
+// the class and the server test project when needed.
+func ScaffoldControllerTest(ctx Context) error {
+	if err := ensureTestProject(); err != nil {
+		return err
+	}
+	ctx.TestNamespace = testNamespace(path.Dir(ctx.ControllerPath))
+	file := testFile(ctx.ControllerPath)
+	rel := fsutil.RelToRoot(file)
+
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		content, err := Render(ControllerTestTmpl, ctx)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return err
+		}
+		if err := editorconfig.WriteFile(file, content); err != nil {
+			return err
+		}
+		output.Created(file, "[CREATED] Controller Test: %s\n", rel)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	f := csharp.Parse(string(data))
+	class := f.Type(ctx.Controller + "Tests")
+	if class == nil || class.Open < 0 {
+		return fmt.Errorf("class %sTests not found in %s", ctx.Controller, rel)
+	}
+	if _, ok := class.Method(ctx.Action + "_ReturnsView"); ok {
+		output.Printf("[EXISTS] Controller Test: %s (%s_ReturnsView)\n", rel, ctx.Action)
+		return nil
+	}
+	method, err := Render(ActionTestTmpl, ctx)
+	if err != nil {
+		return err
+	}
+	if err := editorconfig.UpdateFile(file, f.InsertMember(class, method)); err != nil {
+		return err
+	}
+	output.Modified(file, "[UPDATED] Controller Test: %s (Added %s_ReturnsView)\n", rel, ctx.Action)
+	return nil
+}
+
+// ensureTestProject creates the server test project if its folder has no .csproj.
+func ensureTestProject() error {
+	dir := ServerTestsDir()
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*.csproj")); len(matches) > 0 {
+		return nil
+	}
+	server, err := dotnet.Server()
+	if err != nil {
+		return err
+	}
+	if server.Path == "" {
+		return fmt.Errorf("no .csproj found in %s", fsutil.RelToRoot(config.ServerDir))
+	}
+	ref, err := filepath.Rel(dir, server.Path)
+	if err != nil {
+		return err
+	}
+	// The tests target the server's framework; there is no safe guess when it is set elsewhere,
+	// e.g. in Directory.Build.props or as several <TargetFrameworks>
+	if server.TargetFramework == "" {
+		return fmt.Errorf("%s sets no <TargetFramework> for the test project to use; create %s yourself",
+			fsutil.RelToRoot(server.Path), fsutil.RelToRoot(filepath.Join(dir, filepath.Base(dir)+".csproj")))
+	}
+	content, err := Render(TestProjectTmpl, TestProjectContext{
+		TargetFramework: server.TargetFramework,
+		ServerProject:   strings.ReplaceAll(ref, "/", `\`),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	file := filepath.Join(dir, filepath.Base(dir)+".csproj")
+	if err := editorconfig.WriteFile(file, content); err != nil {
+		return err
+	}
+	output.Created(file, "[CREATED] Test Project: %s\n", fsutil.RelToRoot(file))
+	output.Printf("[INFO] Add it to the solution: dotnet sln add %s\n", fsutil.RelToRoot(file))
+	return nil
+}
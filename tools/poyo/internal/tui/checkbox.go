@@ -82,7 +82,7 @@ func Checkbox(title string, choices []Choice) ([]string, error) {
 		cursor:   0,
 	}
 
-	p := tea.NewProgram(m)
+	p := tea.NewProgram(m, tea.WithOutput(Output))
 	res, err := p.Run()
 	if err != nil {
 		return nil, err
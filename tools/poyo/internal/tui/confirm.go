@@ -51,7 +51,7 @@ func (m confirmModel) View() string {
 }
 
 func Confirm(question string) (bool, error) {
-	p := tea.NewProgram(confirmModel{question: question, yes: false})
+	p := tea.NewProgram(confirmModel{question: question, yes: false}, tea.WithOutput(Output))
 	m, err := p.Run()
 	if err != nil {
 		return false, err
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type inputModel struct {
+	prompt    string
+	input     textinput.Model
+	done      bool
+	cancelled bool
+}
+
+func (m inputModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m inputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			return m, tea.Quit
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m inputModel) View() string {
+	if m.done || m.cancelled {
+		return fmt.Sprintf("%s %s\n", m.prompt, m.input.Value())
+	}
+	return fmt.Sprintf("%s %s\n", m.prompt, m.input.View())
+}
+
+// Input asks for a line of text, pre-filled with value. Enter accepts, Esc keeps the original value.
+func Input(prompt, value string) (string, error) {
+	ti := textinput.New()
+	ti.SetValue(value)
+	ti.CursorEnd()
+	ti.Focus()
+
+	p := tea.NewProgram(inputModel{prompt: prompt, input: ti}, tea.WithOutput(Output))
+	res, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+
+	finalModel := res.(inputModel)
+	if finalModel.cancelled {
+		return value, nil
+	}
+	return finalModel.input.Value(), nil
+}
@@ -115,7 +115,7 @@ func Select(title string, choices []Choice) (string, error) {
 
 	m := selectModel{list: l}
 
-	p := tea.NewProgram(m)
+	p := tea.NewProgram(m, tea.WithOutput(Output))
 	res, err := p.Run()
 	if err != nil {
 		return "", err
@@ -0,0 +1,9 @@
+package tui
+
+import (
+	"io"
+	"os"
+)
+
+// Output is where prompts are rendered. It is switched to stderr when stdout carries JSON.
+var Output io.Writer = os.Stdout